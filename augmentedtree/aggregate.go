@@ -0,0 +1,88 @@
+package augmentedtree
+
+func (n *node[P, V]) updateAggregate(combine func(a, b V) V) {
+	aggregate, minHigh := n.entry.value, n.entry.interval.high
+	if n.entry.interval.IsEmpty() { // empty interval never matches
+		minHigh = point[P]{bias: negativeInfinity}
+	}
+	if left := n.leftChild(); left != nil {
+		aggregate = combine(left.aggregate, aggregate)
+		if left.minHigh.lessThan(minHigh) {
+			minHigh = left.minHigh
+		}
+	}
+	if right := n.rightChild(); right != nil {
+		aggregate = combine(aggregate, right.aggregate)
+		if right.minHigh.lessThan(minHigh) {
+			minHigh = right.minHigh
+		}
+	}
+	n.aggregate, n.minHigh = aggregate, minHigh
+}
+
+// aggregateOf combines values intersecting with query in order, lowUpper is the upper limit
+// of sub-tree low points inherited from ancestors, when every sub-tree low point is less than
+// query high point and every sub-tree high point is greater than query low point,
+// the whole sub-tree matches and its aggregate is used directly
+func (n *node[P, V]) aggregateOf(query *Interval[P], lowUpper point[P], add func(V)) {
+	if !n.minMax.hasIntersection(query) {
+		return
+	}
+	if lowUpper.lessThan(query.high) && query.low.lessThan(n.minHigh) {
+		add(n.aggregate)
+		return
+	}
+	if left := n.leftChild(); left != nil {
+		left.aggregateOf(query, n.entry.interval.low, add)
+	}
+	if n.entry.interval.hasIntersection(query) {
+		add(n.entry.value)
+	}
+	if right := n.rightChild(); right != nil {
+		right.aggregateOf(query, lowUpper, add)
+	}
+}
+
+// SetAggregate specifies an associative function which combines values,
+// aggregate of each sub-tree is maintained through modification,
+// e.g. func(a, b int) int { return a + b } for sum or max function for maximum.
+// Setting aggregate function on a non-empty tree recomputes every node in O(n),
+// nil disables aggregate
+func (t *Tree[P, V]) SetAggregate(combine func(a, b V) V) {
+	t.combine = combine
+	if t.root == nil || combine == nil {
+		return
+	}
+	var refresh func(*node[P, V])
+	refresh = func(n *node[P, V]) {
+		for _, direction := range [2]direction{left, right} {
+			if child := t.ownChild(n, direction); child != nil {
+				refresh(child)
+			}
+		}
+		n.update(combine)
+	}
+	refresh(t.ownRoot())
+}
+
+// QueryAggregate combines values of entries intersecting with specified interval in order,
+// returns false if no entry matches or aggregate function not specified.
+// Aggregate of a sub-tree is used directly only if all of its entries intersect,
+// which takes O(log n) for queries over non-nested intervals, e.g. disjoint reservations.
+// Worst case is O(n), e.g. short intervals ending before query interleaved with long
+// intervals covering it, since a tree ordered by low point can't separate them into sub-trees
+func (t *view[P, V]) QueryAggregate(interval Interval[P]) (V, bool) {
+	var aggregate V
+	if t.root == nil || t.combine == nil || interval.IsEmpty() {
+		return aggregate, false
+	}
+	found := false
+	t.root.aggregateOf(&interval, point[P]{bias: positiveInfinity}, func(value V) {
+		if found {
+			aggregate = t.combine(aggregate, value)
+		} else {
+			aggregate, found = value, true
+		}
+	})
+	return aggregate, found
+}
@@ -0,0 +1,67 @@
+package augmentedtree
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueryAggregate(t *testing.T) {
+	tree := Tree[intPoint[int], int]{}
+	_, ok := tree.QueryAggregate(newInterval(0, 0))
+	assert.False(t, ok)
+
+	random := rand.New(rand.NewSource(0))
+	for i := range 100 {
+		tree.Put(newInterval(random.Intn(100), random.Intn(100)), i)
+	}
+	_, ok = tree.QueryAggregate(newInterval(0, 0))
+	assert.False(t, ok, "aggregate function not specified")
+
+	tree.SetAggregate(func(a, b int) int { return a + b })
+	snapshot := tree.Snapshot()
+	for i := range 300 {
+		interval := newInterval(random.Intn(100), random.Intn(100))
+		if i%3 == 0 {
+			tree.Delete(tree.Min().Interval())
+		} else {
+			tree.Put(interval, i)
+		}
+		query := newInterval(random.Intn(100), random.Intn(100))
+		expected, count := 0, 0
+		for entry := range tree.Query(query) {
+			expected += entry.Value()
+			count++
+		}
+		sum, ok := tree.QueryAggregate(query)
+		assert.Equal(t, count > 0, ok)
+		assert.Equal(t, expected, sum)
+	}
+
+	expected := 0
+	for _, value := range snapshot.All() {
+		expected += value
+	}
+	all := NewInterval(newPoint(0), newPoint(0), Unbounded, Unbounded)
+	sum, _ := snapshot.QueryAggregate(all)
+	assert.Equal(t, expected, sum)
+}
+
+func TestQueryAggregateMax(t *testing.T) {
+	tree := Tree[intPoint[int], int]{}
+	tree.SetAggregate(func(a, b int) int { return max(a, b) })
+	tree.Put(newInterval(0, 100), 1)
+	tree.Put(newInterval(10, 20), 5)
+	tree.Put(newInterval(15, 50), 3)
+	tree.Put(NewInterval(newPoint(20), newPoint(20), Closed, Open), 10)
+	priority, _ := tree.QueryAggregate(newInterval(18, 18))
+	assert.Equal(t, 5, priority)
+	priority, _ = tree.QueryAggregate(newInterval(30, 30))
+	assert.Equal(t, 3, priority)
+	priority, _ = tree.QueryAggregate(newInterval(20, 20))
+	assert.Equal(t, 5, priority)
+	assert.True(t, tree.Delete(newInterval(10, 20)))
+	priority, _ = tree.QueryAggregate(newInterval(18, 18))
+	assert.Equal(t, 3, priority)
+}
@@ -0,0 +1,71 @@
+package augmentedtree
+
+// arena allocates nodes in slabs, released nodes are linked by parent pointer as free list
+type arena[P Point[P], V any] struct {
+	slab     []node[P, V]
+	free     *node[P, V]
+	slabSize int
+}
+
+func (a *arena[P, V]) alloc() *node[P, V] {
+	if n := a.free; n != nil {
+		a.free, n.parent = n.parent, nil
+		return n
+	}
+	if len(a.slab) == 0 {
+		a.slab = make([]node[P, V], a.slabSize)
+	}
+	n := &a.slab[0]
+	a.slab = a.slab[1:]
+	return n
+}
+
+func (a *arena[P, V]) release(n *node[P, V]) {
+	*n = node[P, V]{parent: a.free}
+	a.free = n
+}
+
+// SetArena makes nodes allocated in slabs with specified number of nodes,
+// and nodes removed by Delete or Reset recycled, which saves allocations for high-churn tree.
+// Nodes shared with snapshots are never recycled. Zero or negative slab size disables arena.
+//
+// Since nodes are reused, entries returned should not be retained after modification.
+func (t *Tree[P, V]) SetArena(slabSize int) {
+	t.arena = nil
+	if slabSize > 0 {
+		t.arena = &arena[P, V]{slabSize: slabSize}
+	}
+}
+
+func (t *Tree[P, V]) alloc() *node[P, V] {
+	if t.arena == nil {
+		return new(node[P, V])
+	}
+	return t.arena.alloc()
+}
+
+// release recycles node removed from tree unless it's shared with snapshots
+func (t *Tree[P, V]) release(n *node[P, V]) {
+	if t.arena != nil && n.gen == t.gen {
+		t.arena.release(n)
+	}
+}
+
+// releaseAll recycles every node of sub-tree not shared with snapshots,
+// descendants of shared node are always shared
+func (t *Tree[P, V]) releaseAll(n *node[P, V]) {
+	if n == nil || n.gen != t.gen {
+		return
+	}
+	t.releaseAll(n.leftChild())
+	t.releaseAll(n.rightChild())
+	t.release(n)
+}
+
+// Reset removes all entries, nodes are recycled if arena is enabled
+func (t *Tree[P, V]) Reset() {
+	if t.arena != nil {
+		t.releaseAll(t.root)
+	}
+	t.root, t.size = nil, 0
+}
@@ -0,0 +1,93 @@
+package augmentedtree
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArena(t *testing.T) {
+	tree := Tree[intPoint[int], int]{}
+	tree.SetArena(64)
+	tree.SetAggregate(func(a, b int) int { return a + b })
+	expected := map[Interval[intPoint[int]]]int{}
+	var snapshot *Snapshot[intPoint[int], int]
+	var snapshotExpected map[Interval[intPoint[int]]]int
+	r := rand.New(rand.NewSource(0))
+	for i := range 5000 {
+		low := r.Intn(200)
+		interval := newInterval(low, low+r.Intn(10))
+		if r.Intn(2) > 0 {
+			tree.Put(interval, i)
+			expected[interval] = i
+		} else {
+			tree.Delete(interval)
+			delete(expected, interval)
+		}
+		if i%1000 == 0 {
+			snapshot = tree.Snapshot()
+			snapshotExpected = map[Interval[intPoint[int]]]int{}
+			for interval, value := range expected {
+				snapshotExpected[interval] = value
+			}
+		}
+	}
+	assert.NoError(t, tree.Validate())
+	assert.NoError(t, snapshot.Validate())
+	for interval, value := range tree.All() {
+		assert.Equal(t, expected[interval], value)
+	}
+	assert.Equal(t, uint64(len(expected)), tree.Size())
+	for interval, value := range snapshot.All() {
+		assert.Equal(t, snapshotExpected[interval], value)
+	}
+	assert.Equal(t, uint64(len(snapshotExpected)), snapshot.Size())
+
+	tree.Reset()
+	assert.Zero(t, tree.Size())
+	assert.NoError(t, snapshot.Validate())
+	assert.Equal(t, uint64(len(snapshotExpected)), snapshot.Size())
+}
+
+func TestArenaAllocs(t *testing.T) {
+	tree := Tree[intPoint[int], struct{}]{}
+	tree.SetArena(1024)
+	for i := range 1024 {
+		tree.Put(newInterval(i, i), struct{}{})
+	}
+	i := 0
+	allocs := testing.AllocsPerRun(1000, func() {
+		tree.Delete(newInterval(i, i))
+		tree.Put(newInterval(i+1024, i+1024), struct{}{})
+		i++
+	})
+	assert.Zero(t, allocs)
+
+	tree.Reset()
+	allocs = testing.AllocsPerRun(1, func() {
+		for i := range 1024 {
+			tree.Put(newInterval(i, i), struct{}{})
+		}
+	})
+	assert.Zero(t, allocs)
+	assert.NoError(t, tree.Validate())
+}
+
+func benchmarkAugmentedTreeChurn(b *testing.B, slabSize int) {
+	tree := Tree[intPoint[int], struct{}]{}
+	tree.SetArena(slabSize)
+	for i := range 1024 {
+		tree.Put(newInterval(i, i), struct{}{})
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := range b.N {
+		tree.Delete(newInterval(i, i))
+		tree.Put(newInterval(i+1024, i+1024), struct{}{})
+	}
+}
+
+func BenchmarkAugmentedTreeChurn(b *testing.B) { benchmarkAugmentedTreeChurn(b, 0) }
+
+func BenchmarkAugmentedTreeChurnArena(b *testing.B) { benchmarkAugmentedTreeChurn(b, 1024) }
@@ -0,0 +1,81 @@
+package augmentedtree
+
+import (
+	"math/bits"
+	"slices"
+)
+
+func NewEntry[P Point[P], V any](interval Interval[P], value V) Entry[P, V] {
+	return Entry[P, V]{interval, value}
+}
+
+func compareEntries[P Point[P], V any](a, b Entry[P, V]) int {
+	return compareIntervals(a.interval, b.interval)
+}
+
+// buildBalanced builds a perfectly balanced sub-tree from sorted entries,
+// nodes at red depth which is the deepest level are colored red
+// so that every path has same number of black nodes
+func (t *Tree[P, V]) buildBalanced(entries []Entry[P, V], depth, redDepth int) *node[P, V] {
+	if len(entries) == 0 {
+		return nil
+	}
+	mid := len(entries) / 2
+	n := t.alloc()
+	n.entry, n.color, n.gen = entries[mid], black, t.gen
+	if depth == redDepth {
+		n.color = red
+	}
+	n.childs[left] = t.buildBalanced(entries[:mid], depth+1, redDepth)
+	n.childs[right] = t.buildBalanced(entries[mid+1:], depth+1, redDepth)
+	for _, child := range n.childs {
+		if child != nil {
+			child.parent = n
+		}
+	}
+	n.update(t.combine)
+	return n
+}
+
+// FromSorted builds tree from entries sorted by interval in O(n),
+// unsorted entries will be sorted first, the latter one wins for identical intervals
+func FromSorted[P Point[P], V any](entries []Entry[P, V]) *Tree[P, V] {
+	tree := new(Tree[P, V])
+	tree.load(sortEntries(entries))
+	return tree
+}
+
+// sortEntries returns sorted unique entries, input is copied if not sorted
+func sortEntries[P Point[P], V any](entries []Entry[P, V]) []Entry[P, V] {
+	for i := 1; i < len(entries); i++ {
+		if compareEntries(entries[i-1], entries[i]) >= 0 {
+			entries = slices.Clone(entries)
+			slices.SortStableFunc(entries, compareEntries)
+			return compactEntries(entries)
+		}
+	}
+	return entries
+}
+
+// load replaces all entries with sorted unique entries in O(n)
+func (t *Tree[P, V]) load(entries []Entry[P, V]) {
+	t.Reset()
+	if len(entries) == 0 {
+		return
+	}
+	t.root = t.buildBalanced(entries, 0, bits.Len(uint(len(entries)))-1)
+	t.root.color, t.size = black, uint64(len(entries))
+}
+
+// compactEntries removes identical intervals from sorted entries in place, keeps the last one
+func compactEntries[P Point[P], V any](entries []Entry[P, V]) []Entry[P, V] {
+	compacted := entries[:0]
+	for _, entry := range entries {
+		if n := len(compacted); n > 0 && compacted[n-1].interval == entry.interval {
+			compacted[n-1] = entry
+			continue
+		}
+		compacted = append(compacted, entry)
+	}
+	return compacted
+}
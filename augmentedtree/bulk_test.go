@@ -0,0 +1,60 @@
+package augmentedtree
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromSorted(t *testing.T) {
+	assert.Zero(t, FromSorted[intPoint[int], int](nil).Size())
+	for _, size := range []int{1, 2, 3, 7, 8, 100, 1023} {
+		entries := make([]Entry[intPoint[int], int], size)
+		for i := range entries {
+			entries[i] = NewEntry(newInterval(i, i+2), i)
+		}
+		tree := FromSorted(entries)
+		assert.Equal(t, uint64(size), tree.Size())
+		assert.Equal(t, newInterval(0, size+1), tree.root.minMax)
+		i := 0
+		for interval, value := range tree.All() {
+			assert.Equal(t, newInterval(i, i+2), interval)
+			assert.Equal(t, i, value)
+			i++
+		}
+		for i := range size {
+			tree.Put(newInterval(i, i+3), i)
+		}
+		for i := range size {
+			assert.True(t, tree.Delete(newInterval(i, i+2)))
+		}
+		assert.Equal(t, uint64(size), tree.Size())
+	}
+}
+
+func TestFromUnsorted(t *testing.T) {
+	random := rand.New(rand.NewSource(0))
+	entries := make([]Entry[intPoint[int], int], 200)
+	expected := Tree[intPoint[int], int]{}
+	for i := range entries {
+		entries[i] = NewEntry(newInterval(random.Intn(50), random.Intn(50)), i)
+		expected.Put(entries[i].interval, i)
+	}
+	first := entries[0]
+	tree := FromSorted(entries)
+	assert.Equal(t, first, entries[0], "input should be untouched")
+	assert.Equal(t, expected.Size(), tree.Size())
+	for interval, value := range expected.All() {
+		assert.Equal(t, value, tree.Get(interval).Value())
+	}
+}
+
+func BenchmarkAugmentedTreeFromSorted(b *testing.B) {
+	entries := make([]Entry[intPoint[int], struct{}], b.N)
+	for i := range entries {
+		entries[i] = NewEntry(newInterval(i, i), struct{}{})
+	}
+	b.ResetTimer()
+	FromSorted(entries)
+}
@@ -0,0 +1,49 @@
+package augmentedtree
+
+import "slices"
+
+// DeleteOverlapping removes every entry intersecting with specified interval,
+// returns removed entries in interval order
+func (t *Tree[P, V]) DeleteOverlapping(interval Interval[P]) []Entry[P, V] {
+	removed := t.QueryAll(interval)
+	slices.SortFunc(removed, compareEntries)
+	t.deleteEntries(removed)
+	return removed
+}
+
+// DeleteFunc removes every entry matching pred, returns removed entries in interval order
+func (t *Tree[P, V]) DeleteFunc(pred func(*Entry[P, V]) bool) []Entry[P, V] {
+	var removed []Entry[P, V]
+	if t.root == nil {
+		return nil
+	}
+	t.root.walk(left, func(entry *Entry[P, V]) bool {
+		if pred(entry) {
+			removed = append(removed, *entry)
+		}
+		return false
+	})
+	t.deleteEntries(removed)
+	return removed
+}
+
+// deleteEntries removes existing entries, when most entries are removed
+// the tree is rebuilt from remaining entries in O(n) instead
+func (t *Tree[P, V]) deleteEntries(entries []Entry[P, V]) {
+	if uint64(len(entries))*2 <= t.size {
+		for _, entry := range entries {
+			t.Delete(entry.interval)
+		}
+		return
+	}
+	remaining := make([]Entry[P, V], 0, t.size-uint64(len(entries)))
+	t.root.walk(left, func(entry *Entry[P, V]) bool {
+		if len(entries) > 0 && entries[0].interval == entry.interval {
+			entries = entries[1:]
+		} else {
+			remaining = append(remaining, *entry)
+		}
+		return false
+	})
+	t.load(remaining)
+}
@@ -0,0 +1,49 @@
+package augmentedtree
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeleteOverlapping(t *testing.T) {
+	tree := Tree[intPoint[int], int]{}
+	assert.Empty(t, tree.DeleteOverlapping(newInterval(0, 10)))
+	for i := range 100 {
+		tree.Put(newInterval(i, i+1), i)
+	}
+	removed := tree.DeleteOverlapping(newInterval(10, 19))
+	assert.Len(t, removed, 11)
+	assert.Equal(t, newInterval(9, 10), removed[0].Interval())
+	assert.Equal(t, newInterval(19, 20), removed[10].Interval())
+	assert.Equal(t, uint64(89), tree.Size())
+	assert.Zero(t, tree.Count(newInterval(11, 18)))
+
+	snapshot := tree.Snapshot()
+	removed = tree.DeleteOverlapping(newInterval(0, 80))
+	assert.Len(t, removed, 70)
+	assert.Equal(t, uint64(19), tree.Size())
+	assert.Equal(t, newInterval(81, 82), tree.Min().Interval())
+	assert.Equal(t, uint64(89), snapshot.Size())
+	tree.Put(newInterval(0, 1), 0)
+	assert.Equal(t, 1, tree.Count(newInterval(0, 0)))
+	assert.Equal(t, newInterval(0, 100), tree.root.minMax)
+}
+
+func TestDeleteFunc(t *testing.T) {
+	tree := Tree[intPoint[int], int]{}
+	for i := range 100 {
+		tree.Put(newInterval(i, i+1), i)
+	}
+	removed := tree.DeleteFunc(func(entry *Entry[intPoint[int], int]) bool {
+		return entry.Value()%10 == 0
+	})
+	assert.Len(t, removed, 10)
+	assert.Equal(t, 90, removed[9].Value())
+	assert.Equal(t, uint64(90), tree.Size())
+	assert.Nil(t, tree.Get(newInterval(50, 51)))
+	removed = tree.DeleteFunc(func(entry *Entry[intPoint[int], int]) bool { return true })
+	assert.Len(t, removed, 90)
+	assert.Zero(t, tree.Size())
+	assert.Nil(t, tree.root)
+}
@@ -0,0 +1,51 @@
+package augmentedtree
+
+import "iter"
+
+// gaps yields uncovered sub-intervals of bound in order, cursor is the end of covered part,
+// sub-trees ending before cursor are fully covered and skipped
+func (n *node[P, V]) gaps(bound *Interval[P], cursor *point[P], yield func(Interval[P]) bool) bool {
+	if n.minMax.high.lessOrEqualThan(*cursor) || n.minMax.low.greaterOrEqualThan(bound.high) {
+		return true
+	}
+	if left := n.leftChild(); left != nil && !left.gaps(bound, cursor, yield) {
+		return false
+	}
+	interval := &n.entry.interval
+	if !interval.IsEmpty() && interval.low.lessThan(bound.high) {
+		if interval.low.greaterThan(*cursor) && !yield(Interval[P]{*cursor, interval.low}) {
+			return false
+		}
+		if interval.high.greaterThan(*cursor) {
+			*cursor = interval.high
+		}
+	}
+	if right := n.rightChild(); right != nil {
+		return right.gaps(bound, cursor, yield)
+	}
+	return true
+}
+
+// Gaps iterates sub-intervals of bound not covered by any entry in order,
+// bounds of gaps are decided by adjacent entries, e.g. gap between [1, 3] and [5, 7] is (3, 5)
+func (t *view[P, V]) Gaps(bound Interval[P]) iter.Seq[Interval[P]] {
+	return func(yield func(Interval[P]) bool) {
+		cursor := bound.low
+		if t.root != nil && !t.root.gaps(&bound, &cursor, yield) {
+			return
+		}
+		if cursor.lessThan(bound.high) {
+			yield(Interval[P]{cursor, bound.high})
+		}
+	}
+}
+
+// FirstGap returns first gap within bound which fits, e.g. long enough
+func (t *view[P, V]) FirstGap(bound Interval[P], fits func(Interval[P]) bool) (Interval[P], bool) {
+	for gap := range t.Gaps(bound) {
+		if fits(gap) {
+			return gap, true
+		}
+	}
+	return Interval[P]{}, false
+}
@@ -0,0 +1,41 @@
+package augmentedtree
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func collectGaps[V any](tree *Tree[intPoint[int], V], bound Interval[intPoint[int]]) []string {
+	var gaps []string
+	for gap := range tree.Gaps(bound) {
+		gaps = append(gaps, gap.String())
+	}
+	return gaps
+}
+
+func TestGaps(t *testing.T) {
+	tree := Tree[intPoint[int], struct{}]{}
+	assert.Equal(t, []string{"0~100"}, collectGaps(&tree, newInterval(0, 100)))
+
+	tree.Put(newInterval(10, 20), struct{}{})
+	tree.Put(newInterval(15, 30), struct{}{})
+	tree.Put(newInterval(12, 13), struct{}{})
+	tree.Put(newInterval(40, 50), struct{}{})
+	tree.Put(NewInterval(newPoint(50), newPoint(60), Open, Open), struct{}{})
+	tree.Put(NewInterval(newPoint(60), newPoint(70), Open, Closed), struct{}{})
+	tree.Put(newInterval(90, 200), struct{}{})
+	expected := []string{"[0~10)", "(30~40)", "60~60", "(70~90)"}
+	assert.Equal(t, expected, collectGaps(&tree, newInterval(0, 100)))
+	assert.Equal(t, []string{"(30~35]"}, collectGaps(&tree, newInterval(15, 35)))
+	assert.Empty(t, collectGaps(&tree, newInterval(40, 50)))
+	unbounded := NewInterval(newPoint(95), newPoint(0), Closed, Unbounded)
+	assert.Equal(t, []string{"(200~+inf)"}, collectGaps(&tree, unbounded))
+
+	fits := func(gap Interval[intPoint[int]]) bool { return gap.High().t-gap.Low().t >= 15 }
+	gap, ok := tree.FirstGap(newInterval(0, 100), fits)
+	assert.True(t, ok)
+	assert.Equal(t, "(70~90)", gap.String())
+	_, ok = tree.FirstGap(newInterval(0, 80), fits)
+	assert.False(t, ok)
+}
@@ -1,6 +1,10 @@
 package augmentedtree
 
-import "fmt"
+import (
+	"cmp"
+	"fmt"
+	"strings"
+)
 
 type PartialOrdered[T any] interface {
 	LessThan(T) bool
@@ -12,25 +16,84 @@ type Point[T any] interface {
 	PartialOrdered[T]
 }
 
-type point[P Point[P]] struct{ inner P }
-
-func (p point[P]) lessThan(rhs point[P]) bool { return p.inner.LessThan(rhs.inner) }
-
-func (p point[P]) lessOrEqualThan(rhs point[P]) bool { return p.inner.LessOrEqualThan(rhs.inner) }
-
-func (p point[P]) greaterThan(rhs point[P]) bool { return rhs.inner.LessThan(p.inner) }
+// Bound specifies whether interval end point is included
+type Bound uint8
+
+const (
+	Closed    Bound = iota // end point is included
+	Open                   // end point is excluded
+	Unbounded              // interval extends to infinity, end point is ignored
+)
+
+// bias places an interval end between points, so that open, closed and unbounded ends
+// are all totally ordered, e.g. closed low end of x is just below x while open low end
+// of x is just above x
+type bias int8
+
+const (
+	negativeInfinity bias = iota - 2
+	below
+	_
+	above
+	positiveInfinity
+)
+
+func (b bias) infinity() int {
+	switch b {
+	case negativeInfinity:
+		return -1
+	case positiveInfinity:
+		return 1
+	}
+	return 0
+}
 
-func (p point[P]) greaterOrEqualThan(rhs point[P]) bool {
-	return rhs.inner.LessOrEqualThan(p.inner)
+type point[P Point[P]] struct {
+	inner P
+	bias  bias
 }
 
+// compare returns -1, 0 or 1 as callers switch on exact result
 func (p point[P]) compare(rhs point[P]) int {
-	if p.lessThan(rhs) {
+	if lhs, rhs := p.bias.infinity(), rhs.bias.infinity(); lhs != 0 || rhs != 0 {
+		return cmp.Compare(lhs, rhs)
+	}
+	if p.inner.LessThan(rhs.inner) {
 		return -1
-	} else if p.greaterThan(rhs) {
+	} else if rhs.inner.LessThan(p.inner) {
 		return 1
 	}
-	return 0
+	return cmp.Compare(p.bias, rhs.bias)
+}
+
+func (p point[P]) lessThan(rhs point[P]) bool { return p.compare(rhs) < 0 }
+
+func (p point[P]) lessOrEqualThan(rhs point[P]) bool { return p.compare(rhs) <= 0 }
+
+func (p point[P]) greaterThan(rhs point[P]) bool { return p.compare(rhs) > 0 }
+
+func (p point[P]) greaterOrEqualThan(rhs point[P]) bool { return p.compare(rhs) >= 0 }
+
+func lowPoint[P Point[P]](value P, bound Bound) point[P] {
+	switch bound {
+	case Open:
+		return point[P]{value, above}
+	case Unbounded:
+		var zero P
+		return point[P]{zero, negativeInfinity}
+	}
+	return point[P]{value, below}
+}
+
+func highPoint[P Point[P]](value P, bound Bound) point[P] {
+	switch bound {
+	case Open:
+		return point[P]{value, below}
+	case Unbounded:
+		var zero P
+		return point[P]{zero, positiveInfinity}
+	}
+	return point[P]{value, above}
 }
 
 type Interval[P Point[P]] struct{ low, high point[P] }
@@ -40,24 +103,84 @@ func (iv *Interval[P]) contains(rhs *Interval[P]) bool {
 }
 
 func (iv *Interval[P]) hasIntersection(rhs *Interval[P]) bool {
-	lower, higher := iv, rhs
-	if lower.low.greaterThan(higher.low) {
-		lower, higher = higher, lower
+	low, high := iv.low, iv.high
+	if rhs.low.greaterThan(low) {
+		low = rhs.low
+	}
+	if rhs.high.lessThan(high) {
+		high = rhs.high
 	}
-	return lower.high.greaterOrEqualThan(higher.low)
+	return low.lessThan(high)
 }
 
+// Low returns low end point, which is meaningless when low end is unbounded
 func (iv *Interval[P]) Low() P { return iv.low.inner }
 
+// High returns high end point, which is meaningless when high end is unbounded
 func (iv *Interval[P]) High() P { return iv.high.inner }
 
+func (iv *Interval[P]) LowBound() Bound {
+	switch iv.low.bias {
+	case above:
+		return Open
+	case negativeInfinity:
+		return Unbounded
+	}
+	return Closed
+}
+
+func (iv *Interval[P]) HighBound() Bound {
+	switch iv.high.bias {
+	case below:
+		return Open
+	case positiveInfinity:
+		return Unbounded
+	}
+	return Closed
+}
+
+// IsEmpty tells whether interval contains no point, e.g. [x, x)
+func (iv *Interval[P]) IsEmpty() bool { return !iv.low.lessThan(iv.high) }
+
 func (iv *Interval[P]) String() string {
-	return fmt.Sprintf("%v~%v", iv.low.inner, iv.high.inner)
+	lowBound, highBound := iv.LowBound(), iv.HighBound()
+	if lowBound == Closed && highBound == Closed {
+		return fmt.Sprintf("%v~%v", iv.low.inner, iv.high.inner)
+	}
+	var buf strings.Builder
+	switch lowBound {
+	case Closed:
+		fmt.Fprintf(&buf, "[%v", iv.low.inner)
+	case Open:
+		fmt.Fprintf(&buf, "(%v", iv.low.inner)
+	case Unbounded:
+		buf.WriteString("(-inf")
+	}
+	buf.WriteRune('~')
+	switch highBound {
+	case Closed:
+		fmt.Fprintf(&buf, "%v]", iv.high.inner)
+	case Open:
+		fmt.Fprintf(&buf, "%v)", iv.high.inner)
+	case Unbounded:
+		buf.WriteString("+inf)")
+	}
+	return buf.String()
 }
 
-func NewInterval[P Point[P]](low, high P) Interval[P] {
-	if !low.LessOrEqualThan(high) {
+// NewInterval creates interval with both end closed by default,
+// optional bounds specifies low end bound and high end bound respectively,
+// e.g. NewInterval(a, b, Closed, Open) creates [a, b)
+func NewInterval[P Point[P]](low, high P, bounds ...Bound) Interval[P] {
+	lowBound, highBound := Closed, Closed
+	if len(bounds) > 0 {
+		lowBound = bounds[0]
+	}
+	if len(bounds) > 1 {
+		highBound = bounds[1]
+	}
+	if lowBound != Unbounded && highBound != Unbounded && !low.LessOrEqualThan(high) {
 		low, high = high, low
 	}
-	return Interval[P]{point[P]{low}, point[P]{high}}
+	return Interval[P]{lowPoint(low, lowBound), highPoint(high, highBound)}
 }
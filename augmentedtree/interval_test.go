@@ -0,0 +1,109 @@
+package augmentedtree
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newPoint(value int) intPoint[int] { return intPoint[int]{value} }
+
+func TestIntervalBounds(t *testing.T) {
+	halfOpen := NewInterval(newPoint(1), newPoint(2), Closed, Open)
+	next := NewInterval(newPoint(2), newPoint(3), Closed, Open)
+	assert.False(t, halfOpen.hasIntersection(&next))
+	assert.True(t, halfOpen.hasIntersection(&halfOpen))
+	closed := newInterval(1, 2)
+	assert.True(t, closed.hasIntersection(&next))
+	assert.True(t, closed.contains(&halfOpen))
+	assert.False(t, halfOpen.contains(&closed))
+
+	open := NewInterval(newPoint(1), newPoint(2), Open, Open)
+	assert.False(t, open.hasIntersection(&Interval[intPoint[int]]{closed.low, closed.low}))
+	assert.Equal(t, Open, open.LowBound())
+	assert.Equal(t, Open, open.HighBound())
+	assert.Equal(t, "(1~2)", open.String())
+	assert.Equal(t, "[1~2)", halfOpen.String())
+	assert.Equal(t, "1~2", closed.String())
+
+	empty := NewInterval(newPoint(1), newPoint(1), Closed, Open)
+	assert.True(t, empty.IsEmpty())
+	assert.False(t, closed.hasIntersection(&empty))
+
+	after := NewInterval(newPoint(5), newPoint(0), Open, Unbounded)
+	assert.Equal(t, Unbounded, after.HighBound())
+	assert.Equal(t, "(5~+inf)", after.String())
+	far := newInterval(1000, 1000)
+	assert.True(t, after.contains(&far))
+	upTo := NewInterval(newPoint(0), newPoint(5), Unbounded, Closed)
+	assert.False(t, after.hasIntersection(&upTo))
+
+	all := NewInterval(newPoint(0), newPoint(0), Unbounded, Unbounded)
+	assert.True(t, all.contains(&after))
+	assert.False(t, after.contains(&all))
+	assert.Equal(t, "(-inf~+inf)", all.String())
+
+	assert.Equal(t, newInterval(1, 2), NewInterval(newPoint(2), newPoint(1)))
+}
+
+func TestIntervalBoundsInTree(t *testing.T) {
+	tree := Tree[intPoint[int], string]{}
+	tree.Put(NewInterval(newPoint(9), newPoint(10), Closed, Open), "9:00")
+	tree.Put(NewInterval(newPoint(10), newPoint(11), Closed, Open), "10:00")
+	tree.Put(NewInterval(newPoint(17), newPoint(0), Closed, Unbounded), "after work")
+	tree.Put(NewInterval(newPoint(0), newPoint(9), Unbounded, Open), "before work")
+	assert.Equal(t, "10:00", tree.MostSpecific(newPoint(10)).Value())
+	assert.Equal(t, "before work", tree.MostSpecific(newPoint(-100)).Value())
+	assert.Equal(t, "after work", tree.MostSpecific(newPoint(100)).Value())
+	assert.Nil(t, tree.MostSpecific(newPoint(11)))
+	assert.Equal(t, 2, tree.Count(newInterval(8, 9)))
+	assert.Equal(t, "(-inf~+inf)", tree.root.minMax.String())
+	assert.True(t, tree.Delete(NewInterval(newPoint(0), newPoint(9), Unbounded, Open)))
+	assert.Equal(t, 1, tree.Count(newInterval(8, 9)))
+	assert.Equal(t, "9:00", tree.Min().Value())
+}
+
+func TestIntervalSameEndsDifferentBounds(t *testing.T) {
+	tree := Tree[intPoint[int], string]{}
+	bounds := []Bound{Closed, Open, Unbounded}
+	for _, low := range bounds {
+		for _, high := range bounds {
+			interval := NewInterval(newPoint(1), newPoint(5), low, high)
+			tree.Put(interval, interval.String())
+			assert.NoError(t, tree.Validate())
+		}
+	}
+	assert.Equal(t, uint64(9), tree.Size())
+	for _, low := range bounds {
+		for _, high := range bounds {
+			interval := NewInterval(newPoint(1), newPoint(5), low, high)
+			assert.True(t, tree.Update(interval, func(value *string) { *value += "!" }))
+			assert.Equal(t, interval.String()+"!", tree.Get(interval).Value())
+		}
+	}
+	assert.True(t, tree.Delete(NewInterval(newPoint(1), newPoint(5), Open, Closed)))
+	assert.True(t, tree.Delete(NewInterval(newPoint(1), newPoint(5), Closed, Open)))
+	assert.False(t, tree.Delete(NewInterval(newPoint(1), newPoint(5), Open, Closed)))
+	assert.Equal(t, uint64(7), tree.Size())
+	assert.NoError(t, tree.Validate())
+}
+
+func TestIntervalMixedBoundsRandom(t *testing.T) {
+	tree := Tree[intPoint[int], int]{}
+	r := rand.New(rand.NewSource(0))
+	for i := range 2000 {
+		low := r.Intn(10)
+		bounds := []Bound{Bound(r.Intn(3)), Bound(r.Intn(3))}
+		interval := NewInterval(newPoint(low), newPoint(low+r.Intn(3)), bounds...)
+		switch r.Intn(3) {
+		case 0:
+			tree.Delete(interval)
+		case 1:
+			tree.Update(interval, func(value *int) { *value = i })
+		default:
+			tree.Put(interval, i)
+		}
+	}
+	assert.NoError(t, tree.Validate())
+}
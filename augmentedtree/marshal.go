@@ -0,0 +1,103 @@
+package augmentedtree
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+)
+
+var boundNames = [...]string{Closed: "closed", Open: "open", Unbounded: "unbounded"}
+
+func (b Bound) MarshalText() ([]byte, error) {
+	if int(b) >= len(boundNames) {
+		return nil, errors.New("not a valid bound")
+	}
+	return []byte(boundNames[b]), nil
+}
+
+func (b *Bound) UnmarshalText(text []byte) error {
+	for bound, name := range boundNames {
+		if name == string(text) {
+			*b = Bound(bound)
+			return nil
+		}
+	}
+	return errors.New("not a valid bound")
+}
+
+// serialEntry is the serialized form of entry, point and value type should be
+// serializable by encoding/json and encoding/gob respectively,
+// e.g. implements encoding.TextMarshaler and encoding.BinaryMarshaler
+type serialEntry[P Point[P], V any] struct {
+	Low       P     `json:"low"`
+	High      P     `json:"high"`
+	LowBound  Bound `json:"lowBound,omitempty"`
+	HighBound Bound `json:"highBound,omitempty"`
+	Value     V     `json:"value"`
+}
+
+func (t view[P, V]) serialEntries() []serialEntry[P, V] {
+	entries := make([]serialEntry[P, V], 0, t.size)
+	if t.root == nil {
+		return entries
+	}
+	t.root.walk(left, func(entry *Entry[P, V]) bool {
+		interval := &entry.interval
+		entries = append(entries, serialEntry[P, V]{
+			interval.Low(), interval.High(),
+			interval.LowBound(), interval.HighBound(),
+			entry.value,
+		})
+		return false
+	})
+	return entries
+}
+
+func (t *Tree[P, V]) loadSerialEntries(serialEntries []serialEntry[P, V]) error {
+	entries := make([]Entry[P, V], len(serialEntries))
+	for i, entry := range serialEntries {
+		if entry.LowBound > Unbounded || entry.HighBound > Unbounded {
+			return errors.New("not a valid bound")
+		}
+		interval := NewInterval(entry.Low, entry.High, entry.LowBound, entry.HighBound)
+		entries[i] = Entry[P, V]{interval, entry.Value}
+	}
+	t.load(sortEntries(entries))
+	return nil
+}
+
+// MarshalJSON encodes entries in interval order
+func (t view[P, V]) MarshalJSON() ([]byte, error) { return json.Marshal(t.serialEntries()) }
+
+// UnmarshalJSON replaces all entries, tree is built in O(n) if entries are sorted
+func (t *Tree[P, V]) UnmarshalJSON(data []byte) error {
+	var entries []serialEntry[P, V]
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return err
+	}
+	return t.loadSerialEntries(entries)
+}
+
+// MarshalBinary encodes entries in interval order with gob
+func (t view[P, V]) MarshalBinary() ([]byte, error) {
+	if t.root == nil {
+		return nil, nil
+	}
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(t.serialEntries()); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary replaces all entries, tree is built in O(n) if entries are sorted
+func (t *Tree[P, V]) UnmarshalBinary(data []byte) error {
+	var entries []serialEntry[P, V]
+	if len(data) > 0 {
+		if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&entries); err != nil {
+			return err
+		}
+	}
+	return t.loadSerialEntries(entries)
+}
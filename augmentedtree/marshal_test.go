@@ -0,0 +1,95 @@
+package augmentedtree
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/qiuchengxuan/go-types/ip"
+)
+
+func TestJSONMarshal(t *testing.T) {
+	tree := Tree[ip.IP, string]{}
+	data, err := json.Marshal(&tree)
+	assert.NoError(t, err)
+	assert.Equal(t, "[]", string(data))
+
+	tree.Put(NewInterval(ip.MustParse("10.0.0.0"), ip.MustParse("10.0.1.0"), Closed, Open), "b")
+	tree.Put(NewInterval(ip.MustParse("1.1.1.1"), ip.MustParse("1.1.1.1")), "a")
+	data, err = json.Marshal(tree)
+	assert.NoError(t, err)
+	expected := `[{"low":"1.1.1.1","high":"1.1.1.1","value":"a"},` +
+		`{"low":"10.0.0.0","high":"10.0.1.0","highBound":"open","value":"b"}]`
+	assert.Equal(t, expected, string(data))
+
+	snapshot := tree.Snapshot()
+	tree.Put(NewInterval(ip.MustParse("2.2.2.2"), ip.MustParse("2.2.2.2")), "c")
+	data, err = json.Marshal(snapshot)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, string(data))
+
+	var actual Tree[ip.IP, string]
+	assert.NoError(t, json.Unmarshal([]byte(expected), &actual))
+	assert.Equal(t, uint64(2), actual.Size())
+	assert.Equal(t, "b", actual.MostSpecific(ip.MustParse("10.0.0.255")).Value())
+	assert.Nil(t, actual.MostSpecific(ip.MustParse("10.0.1.0")))
+
+	unsorted := `[{"low":"3.3.3.3","high":"3.3.3.3","value":"c"},` +
+		`{"low":"0.0.0.0","high":"9.9.9.9","lowBound":"unbounded","value":"d"}]`
+	assert.NoError(t, json.Unmarshal([]byte(unsorted), &actual))
+	assert.Equal(t, uint64(2), actual.Size())
+	assert.Equal(t, "d", actual.Min().Value())
+	assert.Equal(t, Unbounded, actual.Min().interval.LowBound())
+
+	invalid := `[{"low":"1.1.1.1","high":"1.1.1.1","lowBound":"half","value":"a"}]`
+	assert.Error(t, json.Unmarshal([]byte(invalid), &actual))
+	assert.Error(t, json.Unmarshal([]byte(`[{"low":"x"}]`), &actual))
+}
+
+func TestBinaryMarshal(t *testing.T) {
+	tree := Tree[Ordered[int], string]{}
+	data, err := tree.MarshalBinary()
+	assert.NoError(t, err)
+	assert.Empty(t, data)
+
+	for i := range 100 {
+		tree.Put(NewInterval(NewOrdered(i), NewOrdered(i+10), Open, Closed), "value")
+	}
+	data, err = tree.MarshalBinary()
+	assert.NoError(t, err)
+
+	var actual Tree[Ordered[int], string]
+	actual.SetAggregate(func(a, b string) string { return a })
+	assert.NoError(t, actual.UnmarshalBinary(data))
+	var expected, intervals []Interval[Ordered[int]]
+	for interval := range tree.All() {
+		expected = append(expected, interval)
+	}
+	for interval := range actual.All() {
+		intervals = append(intervals, interval)
+	}
+	assert.Equal(t, expected, intervals)
+	assert.Equal(t, 10, actual.Count(NewInterval(NewOrdered(10), NewOrdered(10))))
+	value, ok := actual.QueryAggregate(NewInterval(NewOrdered(10), NewOrdered(10)))
+	assert.True(t, ok)
+	assert.Equal(t, "value", value)
+	assert.Error(t, actual.UnmarshalBinary(data[:len(data)/2]))
+
+	assert.NoError(t, actual.UnmarshalBinary(nil))
+	assert.Zero(t, actual.Size())
+}
+
+func TestBinaryMarshalTime(t *testing.T) {
+	base := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	tree := Tree[Time, int]{}
+	tree.Put(NewInterval(NewTime(base), NewTime(base.Add(time.Hour)), Closed, Open), 1)
+	data, err := tree.MarshalBinary()
+	assert.NoError(t, err)
+	var actual Tree[Time, int]
+	assert.NoError(t, actual.UnmarshalBinary(data))
+	if entry := actual.MostSpecific(NewTime(base)); assert.NotNil(t, entry) {
+		assert.Equal(t, 1, entry.Value())
+	}
+}
@@ -0,0 +1,196 @@
+package augmentedtree
+
+// blackHeight returns number of black nodes from sub-tree root to a leaf
+func (n *node[P, V]) blackHeight() int {
+	height := 0
+	for ; n != nil; n = n.leftChild() {
+		if n.color == black {
+			height++
+		}
+	}
+	return height
+}
+
+// blackRoot detaches sub-tree root from its parent and makes it black,
+// returns owned root with black height updated
+func (t *Tree[P, V]) blackRoot(n *node[P, V], height int) (*node[P, V], int) {
+	if n == nil {
+		return nil, height
+	}
+	n = t.own(n)
+	n.parent = nil
+	if n.color == red {
+		n.color = black
+		height++
+	}
+	return n, height
+}
+
+// join concatenates sub-trees l and r with node k in between in O(|lh-rh|),
+// every entry of l must be less than k and every entry of r must be greater than k,
+// returns joined sub-tree with its black height
+func (t *Tree[P, V]) join(
+	l *node[P, V], lh int, k *node[P, V], r *node[P, V], rh int,
+) (*node[P, V], int) {
+	l, lh = t.blackRoot(l, lh)
+	r, rh = t.blackRoot(r, rh)
+	k.color, k.parent = red, nil
+	if lh == rh {
+		k.childs = [2]*node[P, V]{l, r}
+		for _, child := range k.childs {
+			if child != nil {
+				child.parent = k
+			}
+		}
+		k.update(t.combine)
+		return k, lh
+	}
+
+	// descend along inner spine of the higher one until a black node with same height
+	root, short, dir, height := l, r, right, lh
+	if rh > lh {
+		root, short, dir, height = r, l, left, rh
+	}
+	target, cur := min(lh, rh), root
+	for h := height; ; cur = cur.child(dir) {
+		if cur.color == black {
+			h--
+		}
+		if child := t.ownChild(cur, dir); child.is(black) && h == target {
+			break
+		}
+	}
+	k.childs[dir], k.childs[dir.opposite()] = short, cur.child(dir)
+	for _, child := range k.childs {
+		if child != nil {
+			child.parent = k
+		}
+	}
+	k.parent, cur.childs[dir] = cur, k
+	k.update(t.combine)
+	top := t.rebalanceAfterInsert(k)
+	if top.parent == nil { // red propagated to root which is recolored to black
+		height++
+	}
+	t.updateFrom(top)
+	return root, height
+}
+
+// split divides sub-tree with specified black height into entries
+// with low point less than at and the others
+func (t *Tree[P, V]) split(
+	n *node[P, V], height int, at point[P],
+) (*node[P, V], int, *node[P, V], int) {
+	if n == nil {
+		return nil, 0, nil, 0
+	}
+	k := t.own(n)
+	if k.color == black {
+		height--
+	}
+	l, r := k.leftChild(), k.rightChild()
+	k.childs = [2]*node[P, V]{}
+	if k.entry.interval.low.lessThan(at) {
+		rl, rlh, rr, rrh := t.split(r, height, at)
+		l, lh := t.join(l, height, k, rl, rlh)
+		return l, lh, rr, rrh
+	}
+	ll, llh, lr, lrh := t.split(l, height, at)
+	r, rh := t.join(lr, lrh, k, r, height)
+	return ll, llh, r, rh
+}
+
+func (t *Tree[P, V]) withRoot(root *node[P, V]) *Tree[P, V] {
+	if root != nil {
+		root.color = black
+	}
+	return &Tree[P, V]{view: view[P, V]{root, uint64(root.sizeOf()), t.combine}, gen: t.gen}
+}
+
+// Split moves entries with low point less than specified point to lower tree
+// and the others to upper tree in O(log n), tree is emptied afterwards.
+// Arena is not carried over to lower and upper tree
+func (t *Tree[P, V]) Split(at P) (lower, upper *Tree[P, V]) {
+	l, _, r, _ := t.split(t.root, t.root.blackHeight(), lowPoint(at, Closed))
+	lower, upper = t.withRoot(l), t.withRoot(r)
+	t.root, t.size = nil, 0
+	return lower, upper
+}
+
+// Join concatenates two trees in O(log n), every interval of a must be ordered
+// before every interval of b, otherwise panics.
+// Both a and b are emptied afterwards, aggregate function of a is used
+// while arena is not carried over.
+// Since functions are not comparable, aggregates of b are recomputed in O(m)
+// when a has aggregate function
+func Join[P Point[P], V any](a, b *Tree[P, V]) *Tree[P, V] {
+	if a.combine != nil {
+		b.SetAggregate(a.combine)
+	}
+	t := &Tree[P, V]{view: view[P, V]{combine: a.combine}, gen: max(a.gen, b.gen)}
+	switch {
+	case b.root == nil:
+		t.root, t.size = a.root, a.size
+	case a.root == nil:
+		t.root, t.size = b.root, b.size
+	default:
+		if compareIntervals(a.Max().interval, b.Min().interval) >= 0 {
+			panic("Intervals of joining trees overlapping")
+		}
+		// borrow minimum entry of b as the node in between
+		entry := *b.Min()
+		b.Delete(entry.interval)
+		k := &node[P, V]{entry: entry, gen: t.gen}
+		root, _ := t.join(a.root, a.root.blackHeight(), k, b.root, b.root.blackHeight())
+		root.color = black
+		t.root, t.size = root, a.size+b.size+1
+	}
+	a.root, a.size = nil, 0
+	b.root, b.size = nil, 0
+	return t
+}
+
+// Union merges entries of other tree into tree, conflict decides value for identical interval.
+// Entries are inserted one by one when other tree is small enough,
+// otherwise the tree is rebuilt from merged entries in O(n+m)
+func (t *Tree[P, V]) Union(other *Tree[P, V], conflict func(old, value V) V) {
+	if other.root == nil {
+		return
+	}
+	if other.size*2 <= t.size {
+		other.root.walk(left, func(entry *Entry[P, V]) bool {
+			t.upsert(entry.interval, func(value *V, exists bool) {
+				if exists {
+					*value = conflict(*value, entry.value)
+				} else {
+					*value = entry.value
+				}
+			})
+			return false
+		})
+		return
+	}
+	var entries []Entry[P, V]
+	if t.root != nil {
+		entries = make([]Entry[P, V], 0, t.size)
+		t.root.walk(left, func(entry *Entry[P, V]) bool {
+			entries = append(entries, *entry)
+			return false
+		})
+	}
+	merged := make([]Entry[P, V], 0, len(entries)+int(other.size))
+	other.root.walk(left, func(entry *Entry[P, V]) bool {
+		for len(entries) > 0 && compareEntries(entries[0], *entry) < 0 {
+			merged, entries = append(merged, entries[0]), entries[1:]
+		}
+		if len(entries) > 0 && entries[0].interval == entry.interval {
+			value := conflict(entries[0].value, entry.value)
+			merged = append(merged, Entry[P, V]{entry.interval, value})
+			entries = entries[1:]
+		} else {
+			merged = append(merged, *entry)
+		}
+		return false
+	})
+	t.load(append(merged, entries...))
+}
@@ -0,0 +1,142 @@
+package augmentedtree
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func intervalsOf[V any](tree *Tree[intPoint[int], V]) []Interval[intPoint[int]] {
+	var intervals []Interval[intPoint[int]]
+	for interval := range tree.All() {
+		intervals = append(intervals, interval)
+	}
+	return intervals
+}
+
+func TestSplit(t *testing.T) {
+	r := rand.New(rand.NewSource(0))
+	for _, n := range []int{0, 1, 2, 3, 10, 100, 1000} {
+		for range 10 {
+			tree := Tree[intPoint[int], int]{}
+			tree.SetAggregate(func(a, b int) int { return a + b })
+			for i := range n {
+				low := r.Intn(n + 1)
+				tree.Put(newInterval(low, low+r.Intn(10)), i)
+			}
+			snapshot := tree.Snapshot()
+			expected := intervalsOf(&tree)
+			at := r.Intn(n + 2)
+			lower, upper := tree.Split(intPoint[int]{at})
+			assert.NoError(t, lower.Validate())
+			assert.NoError(t, upper.Validate())
+			assert.NoError(t, snapshot.Validate())
+			assert.Zero(t, tree.Size())
+			assert.Equal(t, uint64(len(expected)), snapshot.Size())
+			for _, interval := range intervalsOf(lower) {
+				assert.Less(t, interval.Low().t, at)
+			}
+			for _, interval := range intervalsOf(upper) {
+				assert.GreaterOrEqual(t, interval.Low().t, at)
+			}
+			assert.Equal(t, expected, append(intervalsOf(lower), intervalsOf(upper)...))
+
+			lower.Put(newInterval(-1, 0), 0)
+			assert.NoError(t, lower.Validate())
+			joined := Join(lower, upper)
+			assert.NoError(t, joined.Validate())
+			assert.Zero(t, lower.Size())
+			assert.Zero(t, upper.Size())
+			expected = append([]Interval[intPoint[int]]{newInterval(-1, 0)},
+				expected...)
+			assert.Equal(t, expected, intervalsOf(joined))
+		}
+	}
+}
+
+func TestJoin(t *testing.T) {
+	a, b := Tree[intPoint[int], int]{}, Tree[intPoint[int], int]{}
+	for i := range 1000 {
+		a.Put(newInterval(i, i+5), i)
+	}
+	for i := range 3 {
+		b.Put(newInterval(1000+i, 2000), i)
+	}
+	assert.Panics(t, func() { Join(&b, &a) })
+	assert.Equal(t, uint64(3), b.Size())
+	joined := Join(&a, &b)
+	assert.NoError(t, joined.Validate())
+	assert.Equal(t, uint64(1003), joined.Size())
+	assert.Equal(t, 6, joined.Count(newInterval(1000, 1000)))
+
+	empty := Tree[intPoint[int], int]{}
+	assert.Equal(t, uint64(1003), Join(&empty, joined).Size())
+}
+
+func TestJoinAggregate(t *testing.T) {
+	sum := func(a, b int) int { return a + b }
+	for _, combine := range []func(a, b int) int{nil, func(a, b int) int { return max(a, b) }} {
+		a, b := Tree[intPoint[int], int]{}, Tree[intPoint[int], int]{}
+		a.SetAggregate(sum)
+		b.SetAggregate(combine)
+		for i := range 100 {
+			a.Put(newInterval(i, i), 1)
+			b.Put(newInterval(100+i, 100+i), 2)
+		}
+		snapshot := b.Snapshot()
+		joined := Join(&a, &b)
+		assert.NoError(t, joined.Validate())
+		total, _ := joined.QueryAggregate(newInterval(0, 200))
+		assert.Equal(t, 300, total)
+		total, _ = joined.QueryAggregate(newInterval(150, 200))
+		assert.Equal(t, 100, total)
+		assert.NoError(t, snapshot.Validate())
+	}
+
+	a, b := Tree[intPoint[int], int]{}, Tree[intPoint[int], int]{}
+	a.SetAggregate(sum)
+	b.Put(newInterval(0, 0), 1)
+	total, _ := Join(&a, &b).QueryAggregate(newInterval(0, 0))
+	assert.Equal(t, 1, total)
+}
+
+func TestUnion(t *testing.T) {
+	sum := func(old, value int) int { return old + value }
+	for _, n := range []int{10, 1000} {
+		tree, other := Tree[intPoint[int], int]{}, Tree[intPoint[int], int]{}
+		for i := range 1000 {
+			tree.Put(newInterval(i*2, i*2+1), 1)
+		}
+		common := 0
+		for i := range n {
+			other.Put(newInterval(i*3, i*3+1), 2)
+			if i*3%2 == 0 && i*3 < 2000 {
+				common++
+			}
+		}
+		snapshot := tree.Snapshot()
+		tree.Union(&other, sum)
+		assert.NoError(t, tree.Validate())
+		assert.NoError(t, snapshot.Validate())
+		assert.Equal(t, uint64(1000+n-common), tree.Size())
+		assert.Equal(t, 3, tree.Get(newInterval(0, 1)).Value())
+		assert.Equal(t, 2, tree.Get(newInterval(3, 4)).Value())
+		assert.Equal(t, 1, tree.Get(newInterval(2, 3)).Value())
+		assert.Equal(t, uint64(n), other.Size())
+		assert.Equal(t, 1, snapshot.Get(newInterval(0, 1)).Value())
+	}
+}
+
+func BenchmarkAugmentedTreeSplitJoin(b *testing.B) {
+	tree := &Tree[intPoint[int], struct{}]{}
+	for i := range 1 << 16 {
+		tree.Put(newInterval(i, i), struct{}{})
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := range b.N {
+		lower, upper := tree.Split(intPoint[int]{i % (1 << 16)})
+		tree = Join(lower, upper)
+	}
+}
@@ -0,0 +1,45 @@
+package augmentedtree
+
+// MultiTree keeps all values for identical intervals instead of overwriting,
+// values of same interval are kept in insertion order
+type MultiTree[P Point[P], V any] struct{ Tree[P, []V] }
+
+// Put appends value to values of specified interval
+func (t *MultiTree[P, V]) Put(interval Interval[P], value V) {
+	t.upsert(interval, func(values *[]V, _ bool) { *values = append(*values, value) })
+}
+
+// Values returns all values of specified interval
+func (t *MultiTree[P, V]) Values(interval Interval[P]) []V {
+	if entry := t.Get(interval); entry != nil {
+		return entry.value
+	}
+	return nil
+}
+
+// DeleteValue removes first value matching pred from values of specified interval,
+// interval is removed when no value left
+func (t *MultiTree[P, V]) DeleteValue(interval Interval[P], pred func(V) bool) bool {
+	entry := t.Get(interval)
+	if entry == nil {
+		return false
+	}
+	index := -1
+	for i, value := range entry.value {
+		if pred(value) {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		return false
+	}
+	if len(entry.value) == 1 {
+		return t.Delete(interval)
+	}
+	// values may be shared with snapshots, so never modify in place
+	values := make([]V, 0, len(entry.value)-1)
+	values = append(append(values, entry.value[:index]...), entry.value[index+1:]...)
+	t.upsert(interval, func(old *[]V, _ bool) { *old = values })
+	return true
+}
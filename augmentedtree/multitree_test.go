@@ -0,0 +1,31 @@
+package augmentedtree
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMultiTree(t *testing.T) {
+	tree := MultiTree[intPoint[int], string]{}
+	tree.Put(newInterval(1, 10), "allow")
+	tree.Put(newInterval(1, 10), "log")
+	tree.Put(newInterval(1, 10), "allow")
+	tree.Put(newInterval(5, 20), "deny")
+	assert.Equal(t, uint64(2), tree.Size())
+	assert.Equal(t, []string{"allow", "log", "allow"}, tree.Values(newInterval(1, 10)))
+	assert.Nil(t, tree.Values(newInterval(1, 2)))
+	assert.Equal(t, 2, tree.Count(newInterval(6, 6)))
+
+	snapshot := tree.Snapshot()
+	isAllow := func(value string) bool { return value == "allow" }
+	assert.True(t, tree.DeleteValue(newInterval(1, 10), isAllow))
+	assert.Equal(t, []string{"log", "allow"}, tree.Values(newInterval(1, 10)))
+	assert.Equal(t, []string{"allow", "log", "allow"}, snapshot.Get(newInterval(1, 10)).Value())
+	assert.True(t, tree.DeleteValue(newInterval(1, 10), isAllow))
+	assert.False(t, tree.DeleteValue(newInterval(1, 10), isAllow))
+	assert.False(t, tree.DeleteValue(newInterval(1, 2), isAllow))
+	assert.True(t, tree.DeleteValue(newInterval(1, 10), func(string) bool { return true }))
+	assert.Nil(t, tree.Get(newInterval(1, 10)))
+	assert.Equal(t, uint64(1), tree.Size())
+}
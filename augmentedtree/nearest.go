@@ -0,0 +1,73 @@
+package augmentedtree
+
+// lastBefore finds entry ending before cut with greatest high point,
+// sub-trees without any low point less than cut or without greater high point are skipped
+func (n *node[P, V]) lastBefore(cut point[P], best *Entry[P, V]) *Entry[P, V] {
+	if !n.minMax.low.lessThan(cut) {
+		return best
+	}
+	if best != nil && !n.minMax.high.greaterThan(best.interval.high) {
+		return best
+	}
+	interval := &n.entry.interval
+	if right := n.rightChild(); right != nil && interval.low.lessThan(cut) {
+		best = right.lastBefore(cut, best)
+	}
+	if !interval.IsEmpty() && interval.high.lessOrEqualThan(cut) {
+		if best == nil || interval.high.greaterThan(best.interval.high) {
+			best = &n.entry
+		}
+	}
+	if left := n.leftChild(); left != nil {
+		best = left.lastBefore(cut, best)
+	}
+	return best
+}
+
+// PrevBefore returns entry entirely before specified point with greatest high point,
+// the narrower one wins for identical high point
+func (t *view[P, V]) PrevBefore(point P) *Entry[P, V] {
+	if t.root == nil {
+		return nil
+	}
+	return t.root.lastBefore(highPoint(point, Open), nil)
+}
+
+// NextAfter returns entry entirely after specified point with smallest low point,
+// the narrower one wins for identical low point
+func (t *view[P, V]) NextAfter(point P) *Entry[P, V] {
+	var next *Entry[P, V]
+	if t.root == nil {
+		return nil
+	}
+	// smallest interval with low point just above specified point
+	from := Interval[P]{lowPoint(point, Open), lowPoint(point, Unbounded)}
+	t.root.seek(from, left, func(entry *Entry[P, V]) bool {
+		if entry.interval.IsEmpty() {
+			return false
+		}
+		next = entry
+		return true
+	})
+	return next
+}
+
+// Nearest returns most specific entry containing specified point, otherwise the closer one
+// of PrevBefore and NextAfter measured by distance function between two points,
+// the previous one wins for identical distance
+func (t *view[P, V]) Nearest(point P, distance func(a, b P) float64) *Entry[P, V] {
+	if entry := t.MostSpecific(point); entry != nil {
+		return entry
+	}
+	prev, next := t.PrevBefore(point), t.NextAfter(point)
+	if prev == nil || next == nil {
+		if prev != nil {
+			return prev
+		}
+		return next
+	}
+	if distance(prev.interval.High(), point) <= distance(point, next.interval.Low()) {
+		return prev
+	}
+	return next
+}
@@ -0,0 +1,88 @@
+package augmentedtree
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNearest(t *testing.T) {
+	tree := Tree[intPoint[int], string]{}
+	distance := func(a, b intPoint[int]) float64 { return float64(b.t - a.t) }
+	assert.Nil(t, tree.PrevBefore(intPoint[int]{0}))
+	assert.Nil(t, tree.NextAfter(intPoint[int]{0}))
+	assert.Nil(t, tree.Nearest(intPoint[int]{0}, distance))
+
+	tree.Put(newInterval(0, 100), "wide")
+	tree.Put(newInterval(10, 20), "a")
+	tree.Put(newInterval(15, 20), "b")
+	tree.Put(newInterval(40, 50), "c")
+	tree.Put(newInterval(40, 45), "d")
+	tree.Put(NewInterval(intPoint[int]{60}, intPoint[int]{70}, Open, Open), "e")
+
+	assert.Equal(t, "b", tree.PrevBefore(intPoint[int]{30}).Value())
+	assert.Equal(t, "d", tree.NextAfter(intPoint[int]{30}).Value())
+	assert.Nil(t, tree.PrevBefore(intPoint[int]{20}))
+	assert.Equal(t, "b", tree.PrevBefore(intPoint[int]{21}).Value())
+	assert.Equal(t, "e", tree.NextAfter(intPoint[int]{60}).Value())
+	assert.Equal(t, "e", tree.PrevBefore(intPoint[int]{70}).Value())
+	assert.Nil(t, tree.NextAfter(intPoint[int]{70}))
+
+	assert.Equal(t, "a", tree.Nearest(intPoint[int]{12}, distance).Value())
+	assert.Equal(t, "wide", tree.Nearest(intPoint[int]{30}, distance).Value())
+	tree.Delete(newInterval(0, 100))
+	assert.Equal(t, "b", tree.Nearest(intPoint[int]{30}, distance).Value())
+	assert.Equal(t, "d", tree.Nearest(intPoint[int]{31}, distance).Value())
+	assert.Equal(t, "a", tree.Nearest(intPoint[int]{-5}, distance).Value())
+	assert.Equal(t, "e", tree.Nearest(intPoint[int]{100}, distance).Value())
+}
+
+func TestNearestRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(0))
+	tree := Tree[intPoint[int], int]{}
+	for i := range 200 {
+		low := r.Intn(1000)
+		tree.Put(newInterval(low, low+r.Intn(20)), i)
+	}
+	for x := range 1000 {
+		var prev, next *Entry[intPoint[int], int]
+		for _, entry := range tree.QueryAll(newInterval(-1, 2000)) {
+			if entry.interval.High().t < x {
+				if prev == nil || prev.interval.High().t < entry.interval.High().t {
+					prev = &entry
+				}
+			}
+			if entry.interval.Low().t > x {
+				if next == nil || next.interval.Low().t > entry.interval.Low().t {
+					next = &entry
+				}
+			}
+		}
+		if actual := tree.PrevBefore(intPoint[int]{x}); prev == nil {
+			assert.Nil(t, actual)
+		} else if assert.NotNil(t, actual) {
+			assert.Equal(t, prev.interval.High(), actual.interval.High())
+		}
+		if actual := tree.NextAfter(intPoint[int]{x}); next == nil {
+			assert.Nil(t, actual)
+		} else if assert.NotNil(t, actual) {
+			assert.Equal(t, next.interval.Low(), actual.interval.Low())
+		}
+	}
+}
+
+func TestNearestTime(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(minutes int) Time {
+		return NewTime(base.Add(time.Duration(minutes) * time.Minute))
+	}
+	tree := Tree[Time, string]{}
+	tree.Put(NewInterval(at(0), at(10), Closed, Open), "first")
+	tree.Put(NewInterval(at(30), at(40), Closed, Open), "second")
+	distance := func(a, b Time) float64 { return float64(b.Time().Sub(a.Time())) }
+	assert.Equal(t, "first", tree.Nearest(at(19), distance).Value())
+	assert.Equal(t, "second", tree.Nearest(at(21), distance).Value())
+	assert.Equal(t, "first", tree.PrevBefore(at(10)).Value())
+}
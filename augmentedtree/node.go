@@ -31,6 +31,11 @@ type node[P Point[P], V any] struct {
 	parent *node[P, V]
 	childs [2]*node[P, V]
 	color  color
+	gen    uint64
+	size   int // number of nodes in sub-tree
+	// only maintained when tree has aggregate function
+	aggregate V
+	minHigh   point[P]
 }
 
 func (n *node[P, V]) grandParent() *node[P, V] { return n.parent.parent }
@@ -39,6 +44,13 @@ func (n *node[P, V]) leftChild() *node[P, V] { return n.childs[left] }
 
 func (n *node[P, V]) rightChild() *node[P, V] { return n.childs[right] }
 
+func (n *node[P, V]) sizeOf() int {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
+
 func (n *node[P, V]) is(color color) bool {
 	if n == nil {
 		return black == color
@@ -70,35 +82,130 @@ func (n *node[P, V]) slibing() *node[P, V] {
 	return n.parent.rightChild()
 }
 
-func (n *node[P, V]) query(interval Interval[P], fn func(*Entry[P, V]) bool) *Entry[P, V] {
-	if interval.hasIntersection(&n.entry.interval) {
+type relation uint8
+
+const (
+	intersecting relation = iota // stored interval intersects with query
+	containing                   // stored interval contains query
+	containedBy                  // stored interval is contained by query
+)
+
+func matches[P Point[P]](r relation, stored, query *Interval[P]) bool {
+	switch r {
+	case containing:
+		return stored.contains(query)
+	case containedBy: // empty interval intersects nothing, neither is it inside anything
+		return !stored.IsEmpty() && query.contains(stored)
+	default:
+		return stored.hasIntersection(query)
+	}
+}
+
+// mayMatch tells whether sub-tree with specified minMax may have matched entry
+func mayMatch[P Point[P]](r relation, minMax, query *Interval[P]) bool {
+	if r == containing {
+		return minMax.contains(query)
+	}
+	return minMax.hasIntersection(query)
+}
+
+func (n *node[P, V]) query(
+	interval Interval[P], r relation, fn func(*Entry[P, V]) bool,
+) *Entry[P, V] {
+	if matches(r, &n.entry.interval, &interval) {
 		if fn(&n.entry) {
 			return &n.entry
 		}
 	}
-	if left := n.leftChild(); left != nil && left.minMax.hasIntersection(&interval) {
-		if retval := left.query(interval, fn); retval != nil {
-			return retval
+	// left sub-tree low point always less or equal than current node
+	if r != containedBy || !n.entry.interval.low.lessThan(interval.low) {
+		if left := n.leftChild(); left != nil && mayMatch(r, &left.minMax, &interval) {
+			if retval := left.query(interval, r, fn); retval != nil {
+				return retval
+			}
 		}
 	}
-	if right := n.rightChild(); right != nil && right.minMax.hasIntersection(&interval) {
-		if retval := right.query(interval, fn); retval != nil {
-			return retval
+	// right sub-tree low point always greater or equal than current node
+	if r != containing || !n.entry.interval.low.greaterThan(interval.low) {
+		if right := n.rightChild(); right != nil && mayMatch(r, &right.minMax, &interval) {
+			if retval := right.query(interval, r, fn); retval != nil {
+				return retval
+			}
 		}
 	}
 	return nil
 }
 
-func (n *node[P, V]) updateMinMax() {
+// walk visits sub-tree entries in order until fn returns true,
+// direction specifies which side to be visited first
+func (n *node[P, V]) walk(dir direction, fn func(*Entry[P, V]) bool) bool {
+	if child := n.child(dir); child != nil && child.walk(dir, fn) {
+		return true
+	}
+	if fn(&n.entry) {
+		return true
+	}
+	if child := n.child(dir.opposite()); child != nil {
+		return child.walk(dir, fn)
+	}
+	return false
+}
+
+// seek visits sub-tree entries in order starting from specified interval,
+// when direction is left visits entries not less than interval in ascending order,
+// otherwise visits entries not greater than interval in descending order
+func (n *node[P, V]) seek(from Interval[P], dir direction, fn func(*Entry[P, V]) bool) bool {
+	cmp := compareIntervals(n.entry.interval, from)
+	if dir == right {
+		cmp = -cmp
+	}
+	if cmp < 0 {
+		if child := n.child(dir.opposite()); child != nil {
+			return child.seek(from, dir, fn)
+		}
+		return false
+	}
+	if child := n.child(dir); child != nil && child.seek(from, dir, fn) {
+		return true
+	}
+	if fn(&n.entry) {
+		return true
+	}
+	if child := n.child(dir.opposite()); child != nil {
+		return child.walk(dir, fn)
+	}
+	return false
+}
+
+// outermost returns left-most or right-most node of sub-tree
+func (n *node[P, V]) outermost(dir direction) *node[P, V] {
+	for n.child(dir) != nil {
+		n = n.child(dir)
+	}
+	return n
+}
+
+// update recomputes sub-tree min/max range and size, and aggregate if combine is not nil
+func (n *node[P, V]) update(combine func(a, b V) V) {
 	low := n.entry.interval.low
 	if left := n.leftChild(); left != nil {
 		low = left.minMax.low
 	}
 	high := n.entry.interval.high
-	if right := n.rightChild(); right != nil {
-		high = right.minMax.high
+	size := 1
+	for _, child := range n.childs {
+		if child == nil {
+			continue
+		}
+		if child.minMax.high.greaterThan(high) {
+			high = child.minMax.high
+		}
+		size += child.size
+	}
+	n.minMax, n.size = Interval[P]{low, high}, size
+	if combine != nil {
+		n.updateAggregate(combine)
 	}
-	n.minMax = Interval[P]{low, high}
 }
 
 func compareIntervals[P Point[P]](a, b Interval[P]) int {
@@ -117,7 +224,7 @@ func compareIntervals[P Point[P]](a, b Interval[P]) int {
  *
  * Without swapping node, swap N and R value
  */
-func (n *node[P, V]) rotateLeft() {
+func (n *node[P, V]) rotateLeft(combine func(a, b V) V) {
 	rightChild := n.rightChild()
 	n.childs[right] = rightChild.rightChild()
 	rightChild.childs = [2]*node[P, V]{n.leftChild(), rightChild.leftChild()}
@@ -130,8 +237,8 @@ func (n *node[P, V]) rotateLeft() {
 	if child := rightChild.leftChild(); child != nil { // A.parent = R
 		child.parent = rightChild
 	}
-	n.leftChild().updateMinMax()
-	n.updateMinMax()
+	n.leftChild().update(combine)
+	n.update(combine)
 }
 
 /*
@@ -143,7 +250,7 @@ func (n *node[P, V]) rotateLeft() {
  *
  * Without swapping node，swap L and N node value
  */
-func (n *node[P, V]) rotateRight() {
+func (n *node[P, V]) rotateRight(combine func(a, b V) V) {
 	leftChild := n.leftChild()
 	n.childs[left] = leftChild.leftChild()
 	leftChild.childs = [2]*node[P, V]{leftChild.rightChild(), n.rightChild()}
@@ -156,15 +263,15 @@ func (n *node[P, V]) rotateRight() {
 	if child := leftChild.rightChild(); child != nil { // C.parent = L
 		child.parent = leftChild
 	}
-	n.rightChild().updateMinMax()
-	n.updateMinMax()
+	n.rightChild().update(combine)
+	n.update(combine)
 }
 
-func (n *node[P, V]) rotate(direction direction) {
+func (n *node[P, V]) rotate(direction direction, combine func(a, b V) V) {
 	if direction == left {
-		n.rotateLeft()
+		n.rotateLeft(combine)
 	} else {
-		n.rotateRight()
+		n.rotateRight(combine)
 	}
 }
 
@@ -0,0 +1,93 @@
+package augmentedtree
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"net/netip"
+	"time"
+)
+
+// Ordered adapts any ordered type to Point
+type Ordered[T cmp.Ordered] struct{ value T }
+
+func NewOrdered[T cmp.Ordered](value T) Ordered[T] { return Ordered[T]{value} }
+
+func (p Ordered[T]) LessThan(rhs Ordered[T]) bool { return cmp.Less(p.value, rhs.value) }
+
+func (p Ordered[T]) LessOrEqualThan(rhs Ordered[T]) bool { return !cmp.Less(rhs.value, p.value) }
+
+func (p Ordered[T]) Value() T { return p.value }
+
+func (p Ordered[T]) String() string { return fmt.Sprint(p.value) }
+
+func (p Ordered[T]) MarshalJSON() ([]byte, error) { return json.Marshal(p.value) }
+
+func (p *Ordered[T]) UnmarshalJSON(data []byte) error { return json.Unmarshal(data, &p.value) }
+
+func (p Ordered[T]) GobEncode() ([]byte, error) {
+	var buf bytes.Buffer
+	err := gob.NewEncoder(&buf).Encode(p.value)
+	return buf.Bytes(), err
+}
+
+func (p *Ordered[T]) GobDecode(data []byte) error {
+	return gob.NewDecoder(bytes.NewReader(data)).Decode(&p.value)
+}
+
+// Time adapts time.Time to Point, monotonic clock reading and location are stripped
+// so that same instants are always equal
+type Time struct{ time time.Time }
+
+func NewTime(t time.Time) Time { return Time{t.Round(0).UTC()} }
+
+func (p Time) LessThan(rhs Time) bool { return p.time.Before(rhs.time) }
+
+func (p Time) LessOrEqualThan(rhs Time) bool { return !rhs.time.Before(p.time) }
+
+func (p Time) Time() time.Time { return p.time }
+
+func (p Time) String() string { return p.time.Format(time.RFC3339Nano) }
+
+func (p Time) MarshalText() ([]byte, error) { return p.time.MarshalText() }
+
+func (p *Time) UnmarshalText(text []byte) error {
+	if err := p.time.UnmarshalText(text); err != nil {
+		return err
+	}
+	*p = NewTime(p.time)
+	return nil
+}
+
+func (p Time) MarshalBinary() ([]byte, error) { return p.time.MarshalBinary() }
+
+func (p *Time) UnmarshalBinary(data []byte) error {
+	if err := p.time.UnmarshalBinary(data); err != nil {
+		return err
+	}
+	*p = NewTime(p.time)
+	return nil
+}
+
+// Addr adapts netip.Addr to Point
+type Addr struct{ addr netip.Addr }
+
+func NewAddr(addr netip.Addr) Addr { return Addr{addr} }
+
+func (p Addr) LessThan(rhs Addr) bool { return p.addr.Less(rhs.addr) }
+
+func (p Addr) LessOrEqualThan(rhs Addr) bool { return p.addr.Compare(rhs.addr) <= 0 }
+
+func (p Addr) Addr() netip.Addr { return p.addr }
+
+func (p Addr) String() string { return p.addr.String() }
+
+func (p Addr) MarshalText() ([]byte, error) { return p.addr.MarshalText() }
+
+func (p *Addr) UnmarshalText(text []byte) error { return p.addr.UnmarshalText(text) }
+
+func (p Addr) MarshalBinary() ([]byte, error) { return p.addr.MarshalBinary() }
+
+func (p *Addr) UnmarshalBinary(data []byte) error { return p.addr.UnmarshalBinary(data) }
@@ -0,0 +1,57 @@
+package augmentedtree
+
+import (
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/qiuchengxuan/go-types/integer/uint128"
+	"github.com/qiuchengxuan/go-types/ip"
+)
+
+func TestOrderedPoint(t *testing.T) {
+	tree := Tree[Ordered[float64], string]{}
+	tree.Put(NewInterval(NewOrdered(0.5), NewOrdered(1.5)), "a")
+	tree.Put(NewInterval(NewOrdered(1.0), NewOrdered(2.0)), "b")
+	assert.Equal(t, 2, tree.Count(NewInterval(NewOrdered(1.2), NewOrdered(1.2))))
+	interval := tree.Max().Interval()
+	assert.Equal(t, 1.0, interval.Low().Value())
+	assert.Equal(t, "0.5~1.5", tree.Min().interval.String())
+}
+
+func TestTimePoint(t *testing.T) {
+	base := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	local := base.In(time.FixedZone("UTC+8", 8*3600))
+	assert.Equal(t, NewTime(base), NewTime(local))
+
+	tree := Tree[Time, string]{}
+	tree.Put(NewInterval(NewTime(base), NewTime(base.Add(time.Hour))), "meeting")
+	at := NewTime(local.Add(30 * time.Minute))
+	assert.Equal(t, "meeting", tree.MostSpecific(at).Value())
+	assert.NotNil(t, tree.Get(NewInterval(NewTime(local), NewTime(local.Add(time.Hour)))))
+}
+
+func TestAddrPoint(t *testing.T) {
+	tree := Tree[Addr, string]{}
+	low, high := netip.MustParseAddr("10.0.0.0"), netip.MustParseAddr("10.0.0.255")
+	tree.Put(NewInterval(NewAddr(low), NewAddr(high)), "10.0.0.0/24")
+	assert.Equal(t, 1, tree.Count(NewInterval(NewAddr(low.Next()), NewAddr(low.Next()))))
+	assert.Equal(t, 0, tree.Count(NewInterval(NewAddr(high.Next()), NewAddr(high.Next()))))
+}
+
+func TestIPPoint(t *testing.T) {
+	tree := Tree[ip.IP, string]{}
+	tree.Put(NewInterval(ip.MustParse("10.0.0.0"), ip.MustParse("10.255.255.255")), "/8")
+	tree.Put(NewInterval(ip.MustParse("10.1.0.0"), ip.MustParse("10.1.255.255")), "/16")
+	assert.Equal(t, "/16", tree.MostSpecific(ip.MustParse("10.1.2.3")).Value())
+	assert.Equal(t, "/8", tree.MostSpecific(ip.MustParse("10.2.0.0")).Value())
+}
+
+func TestU128Point(t *testing.T) {
+	tree := Tree[uint128.U128, int]{}
+	tree.Put(NewInterval(uint128.FromPrimitive(1), uint128.FromPrimitive(10)), 1)
+	query := NewInterval(uint128.FromPrimitive(5), uint128.FromPrimitive(20))
+	assert.Equal(t, 1, tree.Count(query))
+}
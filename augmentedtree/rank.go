@@ -0,0 +1,34 @@
+package augmentedtree
+
+// Select returns the k-th smallest entry counting from 0 in O(log n),
+// returns nil if k is out of range
+func (t *view[P, V]) Select(k int) *Entry[P, V] {
+	for cur := t.root; cur != nil; {
+		leftSize := cur.leftChild().sizeOf()
+		switch {
+		case k < leftSize:
+			cur = cur.leftChild()
+		case k == leftSize:
+			return &cur.entry
+		default:
+			k -= leftSize + 1
+			cur = cur.rightChild()
+		}
+	}
+	return nil
+}
+
+// Rank returns number of entries less than specified interval in O(log n),
+// which is the index of interval if exists
+func (t *view[P, V]) Rank(interval Interval[P]) int {
+	rank := 0
+	for cur := t.root; cur != nil; {
+		if compareIntervals(interval, cur.entry.interval) <= 0 {
+			cur = cur.leftChild()
+			continue
+		}
+		rank += cur.leftChild().sizeOf() + 1
+		cur = cur.rightChild()
+	}
+	return rank
+}
@@ -0,0 +1,36 @@
+package augmentedtree
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelectRank(t *testing.T) {
+	tree := Tree[intPoint[int], int]{}
+	assert.Nil(t, tree.Select(0))
+	assert.Zero(t, tree.Rank(newInterval(0, 0)))
+
+	random := rand.New(rand.NewSource(0))
+	for i := range 500 {
+		tree.Put(newInterval(random.Intn(1000), random.Intn(1000)), i)
+		if i%4 == 0 {
+			tree.Delete(tree.Select(random.Intn(int(tree.Size()))).Interval())
+		}
+	}
+	tree.DeleteOverlapping(newInterval(100, 200))
+	i := 0
+	for interval := range tree.All() {
+		assert.Equal(t, interval, tree.Select(i).Interval())
+		assert.Equal(t, i, tree.Rank(interval))
+		i++
+	}
+	assert.Equal(t, int(tree.Size()), i)
+	assert.Nil(t, tree.Select(i))
+	assert.Nil(t, tree.Select(-1))
+	assert.Equal(t, i, tree.Rank(newInterval(1000, 1000)))
+
+	bulk := FromSorted(tree.QueryAll(newInterval(0, 1000)))
+	assert.Equal(t, int(bulk.Size()), bulk.root.size)
+}
@@ -0,0 +1,63 @@
+package augmentedtree
+
+// Snapshot is an immutable version of tree, which is safe to be queried concurrently
+// while the tree keeps being modified.
+type Snapshot[P Point[P], V any] struct{ view[P, V] }
+
+// Snapshot returns an immutable version of tree in O(1),
+// nodes are shared until tree modifies them, which copies only the touched path.
+//
+// Snapshot never follows parent pointers, since shared nodes may be re-parented by tree.
+func (t *Tree[P, V]) Snapshot() *Snapshot[P, V] {
+	t.gen++
+	return &Snapshot[P, V]{t.view}
+}
+
+// own makes a private copy of node if it's shared with snapshots
+func (t *Tree[P, V]) own(n *node[P, V]) *node[P, V] {
+	if n.gen == t.gen {
+		return n
+	}
+	copied := t.alloc()
+	*copied = *n
+	copied.gen = t.gen
+	for _, child := range copied.childs {
+		if child != nil {
+			child.parent = copied
+		}
+	}
+	return copied
+}
+
+func (t *Tree[P, V]) ownRoot() *node[P, V] {
+	t.root = t.own(t.root)
+	return t.root
+}
+
+// ownChild makes child of an owned parent owned, returns nil if child is nil
+func (t *Tree[P, V]) ownChild(parent *node[P, V], direction direction) *node[P, V] {
+	child := parent.child(direction)
+	if child == nil || child.gen == t.gen {
+		return child
+	}
+	child = t.own(child)
+	child.parent = parent
+	parent.childs[direction] = child
+	return child
+}
+
+// ownPath makes every node from root to the node with specified interval owned,
+// interval must exist
+func (t *Tree[P, V]) ownPath(interval Interval[P]) *node[P, V] {
+	cur := t.ownRoot()
+	for {
+		switch compareIntervals(interval, cur.entry.interval) {
+		case 0:
+			return cur
+		case -1:
+			cur = t.ownChild(cur, left)
+		case 1:
+			cur = t.ownChild(cur, right)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package augmentedtree
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSnapshot(t *testing.T) {
+	tree := Tree[intPoint[int], int]{}
+	for i := range 100 {
+		tree.Put(newInterval(i, i+1), i)
+	}
+	snapshot := tree.Snapshot()
+	for i := range 50 {
+		assert.True(t, tree.Delete(newInterval(i, i+1)))
+	}
+	tree.Put(newInterval(60, 61), -1)
+	tree.Put(newInterval(200, 201), 200)
+
+	assert.Equal(t, uint64(100), snapshot.Size())
+	i := 0
+	for interval, value := range snapshot.All() {
+		assert.Equal(t, newInterval(i, i+1), interval)
+		assert.Equal(t, i, value)
+		i++
+	}
+	assert.Equal(t, 100, i)
+	assert.Equal(t, 60, snapshot.Get(newInterval(60, 61)).Value())
+	assert.Nil(t, snapshot.Get(newInterval(200, 201)))
+
+	assert.Equal(t, uint64(51), tree.Size())
+	assert.Nil(t, tree.Get(newInterval(0, 1)))
+	assert.Equal(t, -1, tree.Get(newInterval(60, 61)).Value())
+
+	// untouched nodes are shared
+	shared := 0
+	for node := range allNodes(snapshot.root) {
+		if node.gen != tree.gen {
+			shared++
+		}
+	}
+	assert.NotZero(t, shared)
+}
+
+func allNodes[P Point[P], V any](n *node[P, V]) func(func(*node[P, V]) bool) {
+	return func(yield func(*node[P, V]) bool) {
+		var walk func(*node[P, V]) bool
+		walk = func(n *node[P, V]) bool {
+			return n == nil || walk(n.leftChild()) && yield(n) && walk(n.rightChild())
+		}
+		walk(n)
+	}
+}
+
+func TestSnapshotConcurrentRead(t *testing.T) {
+	tree := Tree[intPoint[int], int]{}
+	for i := range 1000 {
+		tree.Put(newInterval(i, i), i)
+	}
+	var wg sync.WaitGroup
+	for range 4 {
+		snapshot := tree.Snapshot()
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 1000 {
+				assert.Equal(t, 1, snapshot.Count(newInterval(i, i)))
+			}
+		}()
+		for i := range 250 {
+			tree.Delete(newInterval(i*4, i*4))
+			tree.Put(newInterval(i*4, i*4), -i)
+		}
+	}
+	wg.Wait()
+}
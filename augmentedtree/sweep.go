@@ -0,0 +1,117 @@
+package augmentedtree
+
+import (
+	"container/heap"
+	"iter"
+)
+
+// overlapping visits entries intersecting with query in interval order until fn returns true
+func (n *node[P, V]) overlapping(query *Interval[P], fn func(*Entry[P, V]) bool) bool {
+	if !n.minMax.hasIntersection(query) {
+		return false
+	}
+	if left := n.leftChild(); left != nil && left.overlapping(query, fn) {
+		return true
+	}
+	if n.entry.interval.hasIntersection(query) && fn(&n.entry) {
+		return true
+	}
+	if right := n.rightChild(); right != nil && n.entry.interval.low.lessThan(query.high) {
+		return right.overlapping(query, fn)
+	}
+	return false
+}
+
+type highPoints[P Point[P]] []point[P]
+
+func (h highPoints[P]) Len() int { return len(h) }
+
+func (h highPoints[P]) Less(i, j int) bool { return h[i].lessThan(h[j]) }
+
+func (h highPoints[P]) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+func (h *highPoints[P]) Push(x any) { *h = append(*h, x.(point[P])) }
+
+func (h *highPoints[P]) Pop() any {
+	last := (*h)[len(*h)-1]
+	*h = (*h)[:len(*h)-1]
+	return last
+}
+
+// MaxOverlap returns the first segment where most entries overlap and the number of them,
+// time complexity is O(n log n)
+func (t *view[P, V]) MaxOverlap() (Interval[P], int) {
+	var segment Interval[P]
+	if t.root == nil {
+		return segment, 0
+	}
+	var highs highPoints[P]
+	depth := 0
+	t.root.walk(left, func(entry *Entry[P, V]) bool {
+		if entry.interval.IsEmpty() {
+			return false
+		}
+		for len(highs) > 0 && highs[0].lessOrEqualThan(entry.interval.low) {
+			heap.Pop(&highs)
+		}
+		heap.Push(&highs, entry.interval.high)
+		if len(highs) > depth {
+			depth = len(highs)
+			// a later entry starting before nearest high point makes it deeper,
+			// so segment ends at nearest high point if depth stays maximum
+			segment = Interval[P]{entry.interval.low, highs[0]}
+		}
+		return false
+	})
+	return segment, depth
+}
+
+// Segments splits bound into elementary segments by end points of intersecting entries,
+// iterates segments covered by at least one entry in order with values of covering entries
+func (t *view[P, V]) Segments(bound Interval[P]) iter.Seq2[Interval[P], []V] {
+	return func(yield func(Interval[P], []V) bool) {
+		if t.root == nil {
+			return
+		}
+		var entries []*Entry[P, V]
+		t.root.overlapping(&bound, func(entry *Entry[P, V]) bool {
+			entries = append(entries, entry)
+			return false
+		})
+		var active []*Entry[P, V]
+		cursor := bound.low
+		for len(entries) > 0 || len(active) > 0 {
+			if len(active) == 0 && entries[0].interval.low.greaterThan(cursor) {
+				cursor = entries[0].interval.low
+			}
+			for len(entries) > 0 && entries[0].interval.low.lessOrEqualThan(cursor) {
+				active, entries = append(active, entries[0]), entries[1:]
+			}
+			end := bound.high
+			if len(entries) > 0 && entries[0].interval.low.lessThan(end) {
+				end = entries[0].interval.low
+			}
+			values := make([]V, len(active))
+			for i, entry := range active {
+				values[i] = entry.value
+				if entry.interval.high.lessThan(end) {
+					end = entry.interval.high
+				}
+			}
+			if !yield(Interval[P]{cursor, end}, values) {
+				return
+			}
+			cursor = end
+			if !cursor.lessThan(bound.high) {
+				return
+			}
+			remaining := active[:0]
+			for _, entry := range active {
+				if entry.interval.high.greaterThan(cursor) {
+					remaining = append(remaining, entry)
+				}
+			}
+			active = remaining
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package augmentedtree
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaxOverlap(t *testing.T) {
+	tree := Tree[intPoint[int], int]{}
+	_, depth := tree.MaxOverlap()
+	assert.Zero(t, depth)
+
+	tree.Put(newInterval(0, 10), 0)
+	tree.Put(newInterval(2, 4), 1)
+	tree.Put(newInterval(3, 8), 2)
+	tree.Put(newInterval(6, 9), 3)
+	tree.Put(NewInterval(newPoint(4), newPoint(6), Open, Open), 4)
+	segment, depth := tree.MaxOverlap()
+	assert.Equal(t, 3, depth)
+	assert.Equal(t, "3~4", segment.String())
+
+	tree.Put(newInterval(7, 7), 5)
+	segment, depth = tree.MaxOverlap()
+	assert.Equal(t, 4, depth)
+	assert.Equal(t, "7~7", segment.String())
+}
+
+func TestMaxOverlapRandom(t *testing.T) {
+	random := rand.New(rand.NewSource(0))
+	tree := Tree[intPoint[int], int]{}
+	for i := range 200 {
+		tree.Put(newInterval(random.Intn(1000), random.Intn(1000)), i)
+	}
+	segment, depth := tree.MaxOverlap()
+	assert.Equal(t, depth, tree.Count(segment))
+	for i := range 1000 {
+		assert.LessOrEqual(t, tree.Count(newInterval(i, i)), depth)
+	}
+}
+
+func TestSegments(t *testing.T) {
+	tree := Tree[intPoint[int], string]{}
+	for range tree.Segments(newInterval(0, 100)) {
+		t.Fail()
+	}
+	tree.Put(newInterval(0, 10), "a")
+	tree.Put(NewInterval(newPoint(5), newPoint(15), Closed, Open), "b")
+	tree.Put(newInterval(20, 30), "c")
+	var segments []string
+	var values [][]string
+	for segment, active := range tree.Segments(newInterval(2, 25)) {
+		segments = append(segments, segment.String())
+		values = append(values, active)
+	}
+	assert.Equal(t, []string{"[2~5)", "5~10", "(10~15)", "20~25"}, segments)
+	assert.Equal(t, [][]string{{"a"}, {"a", "b"}, {"b"}, {"c"}}, values)
+
+	segments = segments[:0]
+	all := NewInterval(newPoint(0), newPoint(0), Unbounded, Unbounded)
+	for segment := range tree.Segments(all) {
+		segments = append(segments, segment.String())
+		if len(segments) == 2 {
+			break
+		}
+	}
+	assert.Equal(t, []string{"[0~5)", "5~10"}, segments)
+}
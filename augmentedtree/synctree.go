@@ -0,0 +1,268 @@
+package augmentedtree
+
+import (
+	"iter"
+	"slices"
+	"sync"
+)
+
+// SyncTree is a concurrent-safe tree guarded by read-write lock,
+// read queries don't block each other.
+//
+// Iterators collect results under read lock and yield them after unlocking,
+// so SyncTree could be accessed or modified inside iteration, at the cost of
+// results being collected entirely even if iteration stops early.
+type SyncTree[P Point[P], V any] struct {
+	mutex sync.RWMutex
+	tree  Tree[P, V]
+}
+
+func copyEntry[P Point[P], V any](entry *Entry[P, V]) *Entry[P, V] {
+	if entry == nil {
+		return nil
+	}
+	copied := *entry
+	return &copied
+}
+
+func (s *SyncTree[P, V]) Put(interval Interval[P], value V) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.tree.Put(interval, value)
+}
+
+func (s *SyncTree[P, V]) Upsert(interval Interval[P], fn func(old V, exists bool) V) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.tree.Upsert(interval, fn)
+}
+
+// UpdateValue is like Tree.Update which updates value in place, fn is invoked with lock held
+func (s *SyncTree[P, V]) UpdateValue(interval Interval[P], fn func(*V)) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.tree.Update(interval, fn)
+}
+
+func (s *SyncTree[P, V]) Delete(interval Interval[P]) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.tree.Delete(interval)
+}
+
+// Update applies batch modification atomically
+func (s *SyncTree[P, V]) Update(fn func(*Tree[P, V])) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	fn(&s.tree)
+}
+
+// Snapshot returns an immutable version of tree which could be queried without lock
+func (s *SyncTree[P, V]) Snapshot() *Snapshot[P, V] {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.tree.Snapshot()
+}
+
+// Get returns a copy of entry with exactly the same interval
+func (s *SyncTree[P, V]) Get(interval Interval[P]) *Entry[P, V] {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return copyEntry(s.tree.Get(interval))
+}
+
+func (s *SyncTree[P, V]) QueryAll(interval Interval[P]) []Entry[P, V] {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.tree.QueryAll(interval)
+}
+
+func (s *SyncTree[P, V]) Count(interval Interval[P]) int {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.tree.Count(interval)
+}
+
+func (s *SyncTree[P, V]) QueryAggregate(interval Interval[P]) (V, bool) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.tree.QueryAggregate(interval)
+}
+
+func (s *SyncTree[P, V]) MostSpecific(point P) *Entry[P, V] {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return copyEntry(s.tree.MostSpecific(point))
+}
+
+func (s *SyncTree[P, V]) PrevBefore(point P) *Entry[P, V] {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return copyEntry(s.tree.PrevBefore(point))
+}
+
+func (s *SyncTree[P, V]) NextAfter(point P) *Entry[P, V] {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return copyEntry(s.tree.NextAfter(point))
+}
+
+func (s *SyncTree[P, V]) Nearest(point P, distance func(a, b P) float64) *Entry[P, V] {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return copyEntry(s.tree.Nearest(point, distance))
+}
+
+func (s *SyncTree[P, V]) MostSpecificFunc(
+	point P, prefer func(a, b *Entry[P, V]) bool,
+) *Entry[P, V] {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return copyEntry(s.tree.MostSpecificFunc(point, prefer))
+}
+
+func (s *SyncTree[P, V]) Min() *Entry[P, V] {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return copyEntry(s.tree.Min())
+}
+
+func (s *SyncTree[P, V]) Max() *Entry[P, V] {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return copyEntry(s.tree.Max())
+}
+
+func (s *SyncTree[P, V]) Floor(interval Interval[P]) *Entry[P, V] {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return copyEntry(s.tree.Floor(interval))
+}
+
+func (s *SyncTree[P, V]) Ceiling(interval Interval[P]) *Entry[P, V] {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return copyEntry(s.tree.Ceiling(interval))
+}
+
+func (s *SyncTree[P, V]) FirstGap(
+	bound Interval[P], fits func(Interval[P]) bool,
+) (Interval[P], bool) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.tree.FirstGap(bound, fits)
+}
+
+func (s *SyncTree[P, V]) MaxOverlap() (Interval[P], int) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.tree.MaxOverlap()
+}
+
+func (s *SyncTree[P, V]) Select(k int) *Entry[P, V] {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return copyEntry(s.tree.Select(k))
+}
+
+func (s *SyncTree[P, V]) Rank(interval Interval[P]) int {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.tree.Rank(interval)
+}
+
+func (s *SyncTree[P, V]) Size() uint64 {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.tree.Size()
+}
+
+func (s *SyncTree[P, V]) Validate() error {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.tree.Validate()
+}
+
+// readLocked collects items under read lock and yields them after unlocking,
+// so that SyncTree could be accessed inside iteration
+func readLocked[T any](mutex *sync.RWMutex, seq iter.Seq[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		mutex.RLock()
+		items := slices.Collect(seq)
+		mutex.RUnlock()
+		for _, item := range items {
+			if !yield(item) {
+				return
+			}
+		}
+	}
+}
+
+func readLocked2[K, V any](mutex *sync.RWMutex, seq iter.Seq2[K, V]) iter.Seq2[K, V] {
+	type pair struct {
+		key   K
+		value V
+	}
+	return func(yield func(K, V) bool) {
+		mutex.RLock()
+		var pairs []pair
+		for key, value := range seq {
+			pairs = append(pairs, pair{key, value})
+		}
+		mutex.RUnlock()
+		for _, pair := range pairs {
+			if !yield(pair.key, pair.value) {
+				return
+			}
+		}
+	}
+}
+
+// copied yields copies of entries which remain valid after unlocking
+func copied[P Point[P], V any](seq iter.Seq[*Entry[P, V]]) iter.Seq[*Entry[P, V]] {
+	return func(yield func(*Entry[P, V]) bool) {
+		for entry := range seq {
+			if !yield(copyEntry(entry)) {
+				return
+			}
+		}
+	}
+}
+
+func (s *SyncTree[P, V]) Query(interval Interval[P]) iter.Seq[*Entry[P, V]] {
+	return readLocked(&s.mutex, copied(s.tree.Query(interval)))
+}
+
+func (s *SyncTree[P, V]) Containing(interval Interval[P]) iter.Seq[*Entry[P, V]] {
+	return readLocked(&s.mutex, copied(s.tree.Containing(interval)))
+}
+
+func (s *SyncTree[P, V]) ContainedBy(interval Interval[P]) iter.Seq[*Entry[P, V]] {
+	return readLocked(&s.mutex, copied(s.tree.ContainedBy(interval)))
+}
+
+func (s *SyncTree[P, V]) All() iter.Seq2[Interval[P], V] {
+	return readLocked2(&s.mutex, s.tree.All())
+}
+
+func (s *SyncTree[P, V]) Ascend(from Interval[P]) iter.Seq2[Interval[P], V] {
+	return readLocked2(&s.mutex, s.tree.Ascend(from))
+}
+
+func (s *SyncTree[P, V]) Descend(from Interval[P]) iter.Seq2[Interval[P], V] {
+	return readLocked2(&s.mutex, s.tree.Descend(from))
+}
+
+func (s *SyncTree[P, V]) Gaps(bound Interval[P]) iter.Seq[Interval[P]] {
+	return readLocked(&s.mutex, s.tree.Gaps(bound))
+}
+
+func (s *SyncTree[P, V]) Segments(bound Interval[P]) iter.Seq2[Interval[P], []V] {
+	return readLocked2(&s.mutex, s.tree.Segments(bound))
+}
+
+func (s *SyncTree[P, V]) String() string {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.tree.String()
+}
@@ -0,0 +1,113 @@
+package augmentedtree
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSyncTree(t *testing.T) {
+	tree := SyncTree[intPoint[int], int]{}
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := range 100 {
+				tree.Put(newInterval(i*100+j, i*100+j+1), j)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := range 100 {
+				for range tree.Query(newInterval(j, j+10)) {
+				}
+				tree.Count(newInterval(j, j))
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, uint64(800), tree.Size())
+	assert.Equal(t, 3, tree.Count(newInterval(1, 2)))
+
+	entry := tree.Get(newInterval(1, 2))
+	tree.Put(newInterval(1, 2), -1)
+	assert.Equal(t, 1, entry.Value())
+	assert.Equal(t, -1, tree.Get(newInterval(1, 2)).Value())
+	tree.Upsert(newInterval(1, 2), func(old int, _ bool) int { return old - 1 })
+	assert.Equal(t, -2, tree.Get(newInterval(1, 2)).Value())
+	assert.True(t, tree.UpdateValue(newInterval(1, 2), func(value *int) { *value *= 2 }))
+	assert.Equal(t, -4, tree.Get(newInterval(1, 2)).Value())
+	assert.False(t, tree.UpdateValue(newInterval(-1, 0), func(*int) {}))
+
+	tree.Update(func(tree *Tree[intPoint[int], int]) {
+		for i := range 800 {
+			tree.Delete(newInterval(i, i+1))
+		}
+	})
+	assert.Zero(t, tree.Size())
+	assert.Nil(t, tree.Min())
+}
+
+func TestSyncTreeAccessInIteration(t *testing.T) {
+	tree := SyncTree[intPoint[int], int]{}
+	for i := range 10 {
+		tree.Put(newInterval(i, i+1), i)
+	}
+	count := 0
+	for entry := range tree.Query(newInterval(0, 10)) {
+		assert.Equal(t, entry.Value(), tree.Get(entry.Interval()).Value())
+		tree.Put(entry.Interval(), -entry.Value())
+		count++
+	}
+	assert.Equal(t, 10, count)
+	for interval, value := range tree.All() {
+		tree.Delete(interval)
+		assert.LessOrEqual(t, value, 0)
+	}
+	assert.Zero(t, tree.Size())
+}
+
+func BenchmarkTreeParallelQuery(b *testing.B) {
+	tree := Tree[intPoint[int], struct{}]{}
+	for i := range 1024 {
+		tree.Put(newInterval(i, i+8), struct{}{})
+	}
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for i := 0; pb.Next(); i++ {
+			tree.Count(newInterval(i%1024, i%1024))
+		}
+	})
+}
+
+func BenchmarkSyncTreeParallelQuery(b *testing.B) {
+	tree := SyncTree[intPoint[int], struct{}]{}
+	for i := range 1024 {
+		tree.Put(newInterval(i, i+8), struct{}{})
+	}
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for i := 0; pb.Next(); i++ {
+			tree.Count(newInterval(i%1024, i%1024))
+		}
+	})
+}
+
+func BenchmarkSyncTreeParallelQueryWithWriter(b *testing.B) {
+	tree := SyncTree[intPoint[int], struct{}]{}
+	for i := range 1024 {
+		tree.Put(newInterval(i, i+8), struct{}{})
+	}
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for i := 0; pb.Next(); i++ {
+			if i%100 == 0 {
+				tree.Put(newInterval(i%1024, i%1024+8), struct{}{})
+				continue
+			}
+			tree.Count(newInterval(i%1024, i%1024))
+		}
+	})
+}
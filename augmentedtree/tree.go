@@ -2,31 +2,40 @@ package augmentedtree
 
 import (
 	"fmt"
+	"iter"
 	"math"
 	"strings"
 )
 
+// view is the read-only part of tree, shared by Tree and Snapshot
+type view[P Point[P], V any] struct {
+	root    *node[P, V]
+	size    uint64
+	combine func(a, b V) V // aggregate function, see SetAggregate
+}
+
 // Augmented tree is a special kind of binary sort tree,
 // each node will keep sub-tree max and min range.
 // Here use red-black tree to maintain self rebalance
 type Tree[P Point[P], V any] struct {
-	root *node[P, V]
-	size uint64
+	view[P, V]
+	gen   uint64 // nodes with different generation are shared with snapshots
+	arena *arena[P, V]
 }
 
-func (t *Tree[P, V]) Get(interval Interval[P]) *Entry[P, V] {
+func (t *view[P, V]) Get(interval Interval[P]) *Entry[P, V] {
 	if t.root == nil {
 		return nil
 	}
 	if !t.root.minMax.contains(&interval) {
 		return nil
 	}
-	return t.root.query(interval, func(entry *Entry[P, V]) bool {
+	return t.root.query(interval, containing, func(entry *Entry[P, V]) bool {
 		return entry.interval == interval
 	})
 }
 
-func (t *Tree[P, V]) QueryAll(interval Interval[P]) []Entry[P, V] {
+func (t *view[P, V]) QueryAll(interval Interval[P]) []Entry[P, V] {
 	if t.root == nil {
 		return nil
 	}
@@ -34,22 +43,167 @@ func (t *Tree[P, V]) QueryAll(interval Interval[P]) []Entry[P, V] {
 		return nil
 	}
 	var items []Entry[P, V]
-	t.root.query(interval, func(entry *Entry[P, V]) bool {
+	t.root.query(interval, intersecting, func(entry *Entry[P, V]) bool {
 		items = append(items, *entry)
 		return false
 	})
 	return items
 }
 
-func (t *Tree[P, V]) Size() uint64 { return t.size }
+// Query iterates entries intersecting with specified interval without allocation,
+// sub-trees not intersecting with interval are skipped
+func (t *view[P, V]) Query(interval Interval[P]) iter.Seq[*Entry[P, V]] {
+	return t.queryBy(interval, intersecting)
+}
+
+func (t *view[P, V]) queryBy(interval Interval[P], r relation) iter.Seq[*Entry[P, V]] {
+	return func(yield func(*Entry[P, V]) bool) {
+		if t.root == nil || !mayMatch(r, &t.root.minMax, &interval) {
+			return
+		}
+		t.root.query(interval, r, func(entry *Entry[P, V]) bool { return !yield(entry) })
+	}
+}
+
+// Containing iterates entries whose interval fully contains specified interval
+func (t *view[P, V]) Containing(interval Interval[P]) iter.Seq[*Entry[P, V]] {
+	return t.queryBy(interval, containing)
+}
+
+// ContainedBy iterates entries whose interval is fully inside specified interval,
+// empty intervals are never yielded just like Query
+func (t *view[P, V]) ContainedBy(interval Interval[P]) iter.Seq[*Entry[P, V]] {
+	return t.queryBy(interval, containedBy)
+}
+
+// MostSpecific returns narrowest entry containing specified point,
+// when two candidates are not nested the one with greater low point wins,
+// which makes tree acts as longest-prefix-match table for prefix intervals
+func (t *view[P, V]) MostSpecific(point P) *Entry[P, V] {
+	return t.MostSpecificFunc(point, func(a, b *Entry[P, V]) bool {
+		return compareIntervals(a.interval, b.interval) > 0
+	})
+}
+
+// MostSpecificFunc is like MostSpecific, but not nested candidates are decided by
+// specified function which returns true if a is preferred over b
+func (t *view[P, V]) MostSpecificFunc(point P, prefer func(a, b *Entry[P, V]) bool) *Entry[P, V] {
+	var best *Entry[P, V]
+	for entry := range t.Containing(NewInterval(point, point)) {
+		switch {
+		case best == nil, best.interval.contains(&entry.interval):
+			best = entry
+		case entry.interval.contains(&best.interval):
+		case prefer(entry, best):
+			best = entry
+		}
+	}
+	return best
+}
+
+// All iterates all entries in interval order
+func (t *view[P, V]) All() iter.Seq2[Interval[P], V] {
+	return func(yield func(Interval[P], V) bool) {
+		if t.root == nil {
+			return
+		}
+		t.root.walk(left, func(entry *Entry[P, V]) bool {
+			return !yield(entry.interval, entry.value)
+		})
+	}
+}
+
+// Min returns entry with smallest interval
+func (t *view[P, V]) Min() *Entry[P, V] {
+	if t.root == nil {
+		return nil
+	}
+	return &t.root.outermost(left).entry
+}
+
+// Max returns entry with largest interval
+func (t *view[P, V]) Max() *Entry[P, V] {
+	if t.root == nil {
+		return nil
+	}
+	return &t.root.outermost(right).entry
+}
+
+// Floor returns entry with largest interval less than or equal to specified interval
+func (t *view[P, V]) Floor(interval Interval[P]) *Entry[P, V] {
+	var floor *Entry[P, V]
+	for cur := t.root; cur != nil; {
+		if compareIntervals(cur.entry.interval, interval) > 0 {
+			cur = cur.leftChild()
+			continue
+		}
+		floor = &cur.entry
+		cur = cur.rightChild()
+	}
+	return floor
+}
+
+// Ceiling returns entry with smallest interval greater than or equal to specified interval
+func (t *view[P, V]) Ceiling(interval Interval[P]) *Entry[P, V] {
+	var ceiling *Entry[P, V]
+	for cur := t.root; cur != nil; {
+		if compareIntervals(cur.entry.interval, interval) < 0 {
+			cur = cur.rightChild()
+			continue
+		}
+		ceiling = &cur.entry
+		cur = cur.leftChild()
+	}
+	return ceiling
+}
 
-func updateMinMaxFrom[P Point[P], V any](node *node[P, V]) {
+// Ascend iterates entries greater than or equal to specified interval in ascending order
+func (t *view[P, V]) Ascend(from Interval[P]) iter.Seq2[Interval[P], V] {
+	return func(yield func(Interval[P], V) bool) {
+		if t.root == nil {
+			return
+		}
+		t.root.seek(from, left, func(entry *Entry[P, V]) bool {
+			return !yield(entry.interval, entry.value)
+		})
+	}
+}
+
+// Descend iterates entries less than or equal to specified interval in descending order
+func (t *view[P, V]) Descend(from Interval[P]) iter.Seq2[Interval[P], V] {
+	return func(yield func(Interval[P], V) bool) {
+		if t.root == nil {
+			return
+		}
+		t.root.seek(from, right, func(entry *Entry[P, V]) bool {
+			return !yield(entry.interval, entry.value)
+		})
+	}
+}
+
+// Count returns number of entries intersecting with specified interval
+func (t *view[P, V]) Count(interval Interval[P]) int {
+	count := 0
+	if t.root == nil || !t.root.minMax.hasIntersection(&interval) {
+		return count
+	}
+	// no iterator closure escapes, so that counting is allocation-free without inlining
+	t.root.query(interval, intersecting, func(*Entry[P, V]) bool {
+		count++
+		return false
+	})
+	return count
+}
+
+func (t *view[P, V]) Size() uint64 { return t.size }
+
+func (t *Tree[P, V]) updateFrom(node *node[P, V]) {
 	for ; node != nil; node = node.parent {
-		node.updateMinMax()
+		node.update(t.combine)
 	}
 }
 
-func rebalanceAfterInsert[P Point[P], V any](cur *node[P, V]) *node[P, V] {
+func (t *Tree[P, V]) rebalanceAfterInsert(cur *node[P, V]) *node[P, V] {
 	for {
 		if cur.parent == nil { // case 1: root node
 			cur.color = black
@@ -61,16 +215,17 @@ func rebalanceAfterInsert[P Point[P], V any](cur *node[P, V]) *node[P, V] {
 		parent := cur.parent
 		grandParent := cur.grandParent()
 		if uncle := cur.uncle(); uncle.is(red) { // case 3: parent and uncle is red
+			uncle = t.ownChild(grandParent, parent.direction().opposite())
 			grandParent.color, parent.color, uncle.color = red, black, black
-			parent.updateMinMax()
-			grandParent.updateMinMax()
+			parent.update(t.combine)
+			grandParent.update(t.combine)
 			cur = grandParent
 			continue
 		}
 		direction := cur.direction()
 		// case 4：grand-parent/parent and parent/node not on same side
 		if parent.direction() != direction {
-			parent.rotate(direction.opposite())
+			parent.rotate(direction.opposite(), t.combine)
 			// After rotation cur is the new sub-node, no need to reassignment
 			parent = cur.parent
 			direction = cur.direction()
@@ -78,65 +233,108 @@ func rebalanceAfterInsert[P Point[P], V any](cur *node[P, V]) *node[P, V] {
 		// case 5: grand-parent/parent and parent/node on the same side
 		if parent.direction() == direction {
 			parent.color, grandParent.color = black, red
-			grandParent.rotate(direction.opposite())
+			grandParent.rotate(direction.opposite(), t.combine)
 		}
 		break
 	}
 	return cur
 }
 
+// newNode creates a red node with zero value
+func (t *Tree[P, V]) newNode(interval Interval[P]) *node[P, V] {
+	t.size++
+	n := t.alloc()
+	n.entry.interval, n.color, n.gen = interval, red, t.gen
+	return n
+}
+
 func (t *Tree[P, V]) Put(interval Interval[P], value V) {
+	t.upsert(interval, func(old *V, _ bool) { *old = value })
+}
+
+// Upsert replaces value of specified interval with fn result in a single traversal,
+// old is zero value when interval not exists
+func (t *Tree[P, V]) Upsert(interval Interval[P], fn func(old V, exists bool) V) {
+	t.upsert(interval, func(value *V, exists bool) { *value = fn(*value, exists) })
+}
+
+// Update modifies value of specified interval in place, returns false if interval not exists.
+// Value shared with snapshots is copied before modification,
+// however memory referenced by value like slice or map is not
+func (t *Tree[P, V]) Update(interval Interval[P], fn func(value *V)) bool {
+	n := t.locate(interval)
+	if n == nil {
+		return false
+	}
+	if n.gen != t.gen { // copy path shared with snapshots
+		n = t.ownPath(interval)
+	}
+	fn(&n.entry.value)
+	if t.combine != nil {
+		t.updateFrom(n)
+	}
+	return true
+}
+
+// upsert locates entry with specified interval or inserts one with zero value,
+// then fn is applied to the entry value in place
+func (t *Tree[P, V]) upsert(interval Interval[P], fn func(value *V, exists bool)) {
 	if t.size+1 == math.MaxUint64 {
 		panic("Maximum size")
 	}
-	newNode := &node[P, V]{minMax: interval, entry: Entry[P, V]{interval, value}, color: red}
 	if t.root == nil {
-		t.root = newNode
+		t.root = t.newNode(interval)
 		t.root.color = black
-		t.size++
+		fn(&t.root.entry.value, false)
+		t.root.update(t.combine)
+		return
 	}
 
-	cur := t.root
-	for newNode != nil {
+	cur := t.ownRoot()
+	for {
+		var direction direction
 		switch compareIntervals(interval, cur.entry.interval) {
 		case 0:
-			cur.entry.value = value
+			fn(&cur.entry.value, true)
+			if t.combine != nil {
+				t.updateFrom(cur)
+			}
 			return
 		case -1:
-			if cur.leftChild() == nil {
-				newNode.parent = cur
-				cur.childs[left], newNode = newNode, nil
-			}
-			cur = cur.leftChild()
+			direction = left
 		case 1:
-			if cur.rightChild() == nil {
-				newNode.parent = cur
-				cur.childs[right], newNode = newNode, nil
-			}
-			cur = cur.rightChild()
+			direction = right
 		}
+		if cur.child(direction) == nil {
+			child := t.newNode(interval)
+			fn(&child.entry.value, false)
+			child.update(t.combine)
+			child.parent = cur
+			cur.childs[direction] = child
+			t.updateFrom(t.rebalanceAfterInsert(child))
+			return
+		}
+		cur = t.ownChild(cur, direction)
 	}
-	t.size++
-	updateMinMaxFrom(rebalanceAfterInsert(cur))
 }
 
-func rebalanceAfterDelete[P Point[P], V any](cur *node[P, V]) *node[P, V] {
+func (t *Tree[P, V]) rebalanceAfterDelete(cur *node[P, V]) {
 	for cur.parent != nil { // case 1: root node
 		dir := cur.direction()
 		parent := cur.parent
-		sibling := cur.slibing()  // sibling won't be nil, because deleted node is black
+		// sibling won't be nil, because deleted node is black
+		sibling := t.ownChild(parent, dir.opposite())
 		if sibling.color == red { // case 2: sibling is red
 			parent.color, sibling.color = red, black
-			parent.rotate(dir)
-			parent, sibling = cur.parent, cur.slibing()
-			dir = cur.direction()
+			parent.rotate(dir, t.combine)
+			parent, dir = cur.parent, cur.direction()
+			sibling = t.ownChild(parent, dir.opposite())
 		}
 		// because case 2, sibling is black
 		// case 3 and 4, sibling and two son of sibling is all black
 		if sibling.leftChild().is(black) && sibling.rightChild().is(black) {
 			if parent.color == black { // case 3: parent is black too
 				sibling.color = red
-				parent.updateMinMax()
 				cur = parent
 				continue
 			} else if parent.color == red { // case 4: parent is red
@@ -146,22 +344,19 @@ func rebalanceAfterDelete[P Point[P], V any](cur *node[P, V]) *node[P, V] {
 		}
 		// case 5: sibling is black, sibling left son is red, right son is black
 		if sibling.child(dir).is(red) && sibling.child(dir.opposite()).is(black) {
-			sibling.color, sibling.child(dir).color = red, black
-			sibling.rotate(dir.opposite())
-			sibling = cur.slibing()
-			dir = cur.direction()
+			sibling.color, t.ownChild(sibling, dir).color = red, black
+			sibling.rotate(dir.opposite(), t.combine)
 		}
 		// case 6: sibling is black, sibling left son is red,
 		// current node is left son of parent
 		sibling.color, parent.color = parent.color, black
-		sibling.child(dir.opposite()).color = black
-		cur.parent.rotate(dir)
+		t.ownChild(sibling, dir.opposite()).color = black
+		parent.rotate(dir, t.combine)
 		break
 	}
-	return cur
 }
 
-func (t *Tree[P, V]) locate(interval Interval[P]) *node[P, V] {
+func (t *view[P, V]) locate(interval Interval[P]) *node[P, V] {
 	for cur := t.root; cur != nil; {
 		if !cur.minMax.contains(&interval) {
 			return nil
@@ -182,25 +377,21 @@ func (t *Tree[P, V]) locate(interval Interval[P]) *node[P, V] {
 }
 
 func (t *Tree[P, V]) Delete(interval Interval[P]) bool {
-	if t.root == nil {
-		return false
-	}
-
-	deleting := t.locate(interval)
-	if deleting == nil {
+	if t.locate(interval) == nil {
 		return false
 	}
 	t.size--
-	if t.root == deleting && t.size == 0 {
+	if t.size == 0 {
+		t.release(t.root)
 		t.root = nil
 		return true
 	}
+	deleting := t.ownPath(interval)
 	if deleting.leftChild() != nil && deleting.rightChild() != nil {
 		// copy predecessor node value and delete predecessor node
-		parent := deleting.leftChild()
-		pre := parent
+		pre := t.ownChild(deleting, left)
 		for pre.rightChild() != nil {
-			pre = pre.rightChild()
+			pre = t.ownChild(pre, right)
 		}
 		deleting.entry = pre.entry
 		deleting = pre
@@ -210,30 +401,25 @@ func (t *Tree[P, V]) Delete(interval Interval[P]) bool {
 		replace = deleting.rightChild()
 	}
 	if replace != nil {
-		*deleting, *replace = *replace, *deleting
-		deleting, replace = replace, deleting
-		replace.parent = deleting.parent
-	} else {
-		replace = deleting
-		replace.minMax = replace.parent.entry.interval
-	}
-	if deleting.color == red || replace.color == red {
-		updateMinMaxFrom(replace)
-		replace.color = black
-	} else {
-		updateMinMaxFrom(rebalanceAfterDelete(replace))
-	}
-	if replace == deleting {
-		if deleting.parent.leftChild() == deleting {
-			deleting.parent.childs[left] = nil
-		} else {
-			deleting.parent.childs[right] = nil
-		}
+		// deleting node must be black with a single red leaf child, take over child value
+		deleting.entry = replace.entry
+		deleting.childs = [2]*node[P, V]{}
+		t.updateFrom(deleting)
+		t.release(replace)
+		return true
+	}
+	// deleting node is a leaf, keep it as placeholder while rebalancing
+	if deleting.color == black {
+		t.rebalanceAfterDelete(deleting)
 	}
+	parent := deleting.parent
+	parent.childs[deleting.direction()] = nil
+	t.updateFrom(parent)
+	t.release(deleting)
 	return true
 }
 
-func (t *Tree[P, V]) String() string {
+func (t *view[P, V]) String() string {
 	if t.root == nil {
 		return ""
 	}
@@ -1,6 +1,8 @@
 package augmentedtree
 
 import (
+	"math/rand"
+	"slices"
 	"strconv"
 	"testing"
 
@@ -51,6 +53,173 @@ func TestAugmentedTree(t *testing.T) {
 	}
 }
 
+func TestAugmentedTreeQuery(t *testing.T) {
+	tree := Tree[intPoint[int], int]{}
+	for i := range 10 {
+		tree.Put(newInterval(i, i+2), i)
+	}
+	var values []int
+	for entry := range tree.Query(newInterval(3, 4)) {
+		values = append(values, entry.Value())
+	}
+	assert.ElementsMatch(t, []int{1, 2, 3, 4}, values)
+	assert.Equal(t, 4, tree.Count(newInterval(3, 4)))
+	assert.Equal(t, 0, tree.Count(newInterval(20, 30)))
+
+	count := 0
+	for range tree.Query(newInterval(0, 10)) {
+		count++
+		if count == 2 {
+			break
+		}
+	}
+	assert.Equal(t, 2, count)
+
+	values = values[:0]
+	for interval, value := range tree.All() {
+		assert.Equal(t, newInterval(value, value+2), interval)
+		values = append(values, value)
+	}
+	assert.Equal(t, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, values)
+
+	tree = Tree[intPoint[int], int]{}
+	tree.Put(newInterval(0, 100), 0)
+	tree.Put(newInterval(1, 2), 1)
+	assert.Equal(t, 1, tree.Count(newInterval(50, 50)))
+
+	allocs := testing.AllocsPerRun(100, func() { tree.Count(newInterval(50, 50)) })
+	assert.Zero(t, allocs)
+}
+
+func TestAugmentedTreeOrdered(t *testing.T) {
+	tree := Tree[intPoint[int], int]{}
+	assert.Nil(t, tree.Min())
+	assert.Nil(t, tree.Max())
+	assert.Nil(t, tree.Floor(newInterval(0, 0)))
+	assert.Nil(t, tree.Ceiling(newInterval(0, 0)))
+	for _, i := range []int{8, 2, 6, 0, 4} {
+		tree.Put(newInterval(i, i+1), i)
+	}
+	assert.Equal(t, 0, tree.Min().Value())
+	assert.Equal(t, 8, tree.Max().Value())
+	assert.Equal(t, 4, tree.Floor(newInterval(4, 9)).Value())
+	assert.Equal(t, 4, tree.Floor(newInterval(5, 6)).Value())
+	assert.Nil(t, tree.Floor(newInterval(-1, 0)))
+	assert.Equal(t, 4, tree.Ceiling(newInterval(3, 3)).Value())
+	assert.Equal(t, 6, tree.Ceiling(newInterval(4, 6)).Value())
+	assert.Nil(t, tree.Ceiling(newInterval(9, 9)))
+
+	var values []int
+	for _, value := range tree.Ascend(newInterval(3, 3)) {
+		values = append(values, value)
+	}
+	assert.Equal(t, []int{4, 6, 8}, values)
+	values = values[:0]
+	for _, value := range tree.Descend(newInterval(6, 7)) {
+		values = append(values, value)
+		if len(values) == 3 {
+			break
+		}
+	}
+	assert.Equal(t, []int{6, 4, 2}, values)
+}
+
+func TestAugmentedTreeContainment(t *testing.T) {
+	tree := Tree[intPoint[int], struct{}]{}
+	random := rand.New(rand.NewSource(0))
+	var intervals []Interval[intPoint[int]]
+	for range 200 {
+		interval := newInterval(random.Intn(100), random.Intn(100))
+		tree.Put(interval, struct{}{})
+		intervals = append(intervals, interval)
+	}
+	for range 100 {
+		query := newInterval(random.Intn(100), random.Intn(100))
+		var containing, containedBy []Interval[intPoint[int]]
+		for _, interval := range intervals {
+			if interval.contains(&query) && !slices.Contains(containing, interval) {
+				containing = append(containing, interval)
+			}
+			if query.contains(&interval) && !slices.Contains(containedBy, interval) {
+				containedBy = append(containedBy, interval)
+			}
+		}
+		var actual []Interval[intPoint[int]]
+		for entry := range tree.Containing(query) {
+			actual = append(actual, entry.Interval())
+		}
+		assert.ElementsMatch(t, containing, actual)
+		actual = actual[:0]
+		for entry := range tree.ContainedBy(query) {
+			actual = append(actual, entry.Interval())
+		}
+		assert.ElementsMatch(t, containedBy, actual)
+	}
+}
+
+func TestAugmentedTreeContainedByEmpty(t *testing.T) {
+	// empty interval lands on leaf or internal node depending on tree shape
+	for size := range 16 {
+		tree := Tree[intPoint[int], struct{}]{}
+		for i := range size {
+			tree.Put(newInterval(i*2, i*2+1), struct{}{})
+		}
+		empty := NewInterval(newPoint(size), newPoint(size), Closed, Open)
+		tree.Put(empty, struct{}{})
+		for entry := range tree.ContainedBy(newInterval(-1, size*2)) {
+			assert.NotEqual(t, empty, entry.Interval())
+		}
+		assert.Equal(t, size, tree.Count(newInterval(-1, size*2)))
+	}
+}
+
+func TestAugmentedTreeMostSpecific(t *testing.T) {
+	tree := Tree[intPoint[int], string]{}
+	assert.Nil(t, tree.MostSpecific(intPoint[int]{0}))
+	tree.Put(newInterval(0, 255), "/24")
+	tree.Put(newInterval(0, 127), "/25")
+	tree.Put(newInterval(64, 127), "/26")
+	tree.Put(newInterval(128, 255), "/25")
+	tree.Put(newInterval(96, 96), "/32")
+	assert.Equal(t, "/25", tree.MostSpecific(intPoint[int]{10}).Value())
+	assert.Equal(t, "/26", tree.MostSpecific(intPoint[int]{100}).Value())
+	assert.Equal(t, "/32", tree.MostSpecific(intPoint[int]{96}).Value())
+	assert.Equal(t, newInterval(128, 255), tree.MostSpecific(intPoint[int]{128}).Interval())
+	assert.Nil(t, tree.MostSpecific(intPoint[int]{256}))
+
+	tree.Put(newInterval(90, 200), "overlap")
+	assert.Equal(t, "/25", tree.MostSpecific(intPoint[int]{150}).Value())
+	prefer := func(a, b *Entry[intPoint[int], string]) bool { return a.Value() > b.Value() }
+	assert.Equal(t, "overlap", tree.MostSpecificFunc(intPoint[int]{150}, prefer).Value())
+}
+
+func TestAugmentedTreeUpsert(t *testing.T) {
+	tree := Tree[intPoint[int], int]{}
+	tree.SetAggregate(func(a, b int) int { return a + b })
+	count := func(old int, exists bool) int {
+		if !exists {
+			assert.Zero(t, old)
+		}
+		return old + 1
+	}
+	for i := range 100 {
+		tree.Upsert(newInterval(i%10, i%10+1), count)
+	}
+	assert.Equal(t, uint64(10), tree.Size())
+	assert.Equal(t, 10, tree.Get(newInterval(3, 4)).Value())
+	snapshot := tree.Snapshot()
+
+	assert.False(t, tree.Update(newInterval(3, 3), func(value *int) { *value = 0 }))
+	assert.True(t, tree.Update(newInterval(3, 4), func(value *int) { *value *= 2 }))
+	assert.True(t, tree.Update(newInterval(3, 4), func(value *int) { *value++ }))
+	assert.Equal(t, 21, tree.Get(newInterval(3, 4)).Value())
+	assert.Equal(t, 10, snapshot.Get(newInterval(3, 4)).Value())
+	sum, _ := tree.QueryAggregate(newInterval(0, 100))
+	assert.Equal(t, 111, sum)
+	assert.NoError(t, tree.Validate())
+	assert.NoError(t, snapshot.Validate())
+}
+
 func TestAugmentedTreeRotationLeft(t *testing.T) {
 	tree := Tree[intPoint[int], struct{}]{}
 	tree.Put(newInterval(1, 2), struct{}{})
@@ -147,3 +316,16 @@ func BenchmarkAugmentedTreeDelete(b *testing.B) {
 		tree.Delete(newInterval(i, i))
 	}
 }
+
+func BenchmarkAugmentedTreeQuery(b *testing.B) {
+	tree := Tree[intPoint[int], struct{}]{}
+	for i := range 1024 {
+		tree.Put(newInterval(i, i+8), struct{}{})
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := range b.N {
+		for range tree.Query(newInterval(i%1024, i%1024)) {
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package augmentedtree
+
+import (
+	"errors"
+	"fmt"
+)
+
+type validation[P Point[P], V any] struct {
+	prev       *Entry[P, V] // in-order predecessor updated through walking
+	parents    bool
+	aggregated bool
+}
+
+// validate checks sub-tree invariants and returns its black height
+func (n *node[P, V]) validate(v *validation[P, V]) (int, error) {
+	if n == nil {
+		return 1, nil
+	}
+	heights := [2]int{}
+	for _, child := range n.childs {
+		if child == nil {
+			continue
+		}
+		if v.parents && child.parent != n {
+			interval := child.entry.interval
+			return 0, fmt.Errorf("parent pointer mismatch at %s", interval.String())
+		}
+		if n.color == red && child.color == red {
+			return 0, fmt.Errorf("red node %s has red child", n.entry.interval.String())
+		}
+	}
+	height, err := n.leftChild().validate(v)
+	if err != nil {
+		return 0, err
+	}
+	heights[left] = height
+	if v.prev != nil && compareIntervals(v.prev.interval, n.entry.interval) >= 0 {
+		return 0, fmt.Errorf("%s not ordered after %s",
+			n.entry.interval.String(), v.prev.interval.String())
+	}
+	v.prev = &n.entry
+	if heights[right], err = n.rightChild().validate(v); err != nil {
+		return 0, err
+	}
+	if heights[left] != heights[right] {
+		return 0, fmt.Errorf("black height mismatch at %s", n.entry.interval.String())
+	}
+
+	low, high, size := n.entry.interval.low, n.entry.interval.high, 1
+	if left := n.leftChild(); left != nil {
+		low = left.minMax.low
+	}
+	for _, child := range n.childs {
+		if child == nil {
+			continue
+		}
+		if child.minMax.high.greaterThan(high) {
+			high = child.minMax.high
+		}
+		size += child.size
+	}
+	if minMax := (Interval[P]{low, high}); n.minMax != minMax {
+		return 0, fmt.Errorf("min/max %s of %s should be %s",
+			n.minMax.String(), n.entry.interval.String(), minMax.String())
+	}
+	if n.size != size {
+		interval := n.entry.interval
+		return 0, fmt.Errorf("size %d of %s should be %d", n.size, interval.String(), size)
+	}
+	if v.aggregated {
+		minHigh := n.entry.interval.high
+		if n.entry.interval.IsEmpty() {
+			minHigh = point[P]{bias: negativeInfinity}
+		}
+		for _, child := range n.childs {
+			if child != nil && child.minHigh.lessThan(minHigh) {
+				minHigh = child.minHigh
+			}
+		}
+		if n.minHigh != minHigh {
+			return 0, fmt.Errorf("min high of %s mismatch", n.entry.interval.String())
+		}
+	}
+	if n.color == black {
+		heights[left]++
+	}
+	return heights[left], nil
+}
+
+// Validate checks structural invariants of tree, including red-black coloring, black height,
+// parent pointers, ordering, sub-tree min/max range and size, returns first violation found.
+// It takes O(n) and is intended for debugging and testing
+func (t *Tree[P, V]) Validate() error { return t.validate(true) }
+
+// Validate checks structural invariants of snapshot like Tree.Validate except parent pointers,
+// which may be repointed by the tree to nodes copied from shared ones
+func (s *Snapshot[P, V]) Validate() error { return s.validate(false) }
+
+func (t *view[P, V]) validate(parents bool) error {
+	if t.root == nil {
+		if t.size != 0 {
+			return fmt.Errorf("size %d of empty tree should be 0", t.size)
+		}
+		return nil
+	}
+	if t.root.parent != nil {
+		return errors.New("root has parent")
+	}
+	if t.root.color != black {
+		return errors.New("root is not black")
+	}
+	v := validation[P, V]{parents: parents, aggregated: t.combine != nil}
+	if _, err := t.root.validate(&v); err != nil {
+		return err
+	}
+	if uint64(t.root.size) != t.size {
+		return fmt.Errorf("size %d should be %d", t.size, t.root.size)
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package augmentedtree
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	tree := Tree[intPoint[int], int]{}
+	assert.NoError(t, tree.Validate())
+	tree.SetAggregate(func(a, b int) int { return a + b })
+	r := rand.New(rand.NewSource(0))
+	for i := range 1000 {
+		low := r.Intn(100)
+		interval := newInterval(low, low+r.Intn(20))
+		if r.Intn(3) > 0 {
+			tree.Put(interval, i)
+		} else {
+			tree.Delete(interval)
+		}
+		if i%100 == 0 {
+			snapshot := tree.Snapshot()
+			defer func() { assert.NoError(t, snapshot.Validate()) }()
+		}
+	}
+	assert.NoError(t, tree.Validate())
+	tree.DeleteOverlapping(newInterval(0, 80))
+	assert.NoError(t, tree.Validate())
+}
+
+func TestValidateViolations(t *testing.T) {
+	build := func() *Tree[intPoint[int], int] {
+		tree := Tree[intPoint[int], int]{}
+		for i := range 10 {
+			tree.Put(newInterval(i, i+1), i)
+		}
+		return &tree
+	}
+
+	tree := build()
+	tree.root.color = red
+	assert.EqualError(t, tree.Validate(), "root is not black")
+
+	tree = build()
+	tree.root.leftChild().parent = nil
+	assert.ErrorContains(t, tree.Validate(), "parent pointer mismatch")
+
+	tree = build()
+	tree.root.leftChild().color ^= 1
+	assert.ErrorContains(t, tree.Validate(), "black height mismatch")
+
+	tree = build()
+	tree.root.minMax = newInterval(0, 100)
+	assert.ErrorContains(t, tree.Validate(), "min/max 0~100")
+
+	tree = build()
+	tree.root.entry, tree.root.leftChild().entry = tree.root.leftChild().entry, tree.root.entry
+	assert.ErrorContains(t, tree.Validate(), "not ordered")
+
+	tree = build()
+	tree.size++
+	assert.EqualError(t, tree.Validate(), "size 11 should be 10")
+
+	tree = build()
+	tree.root.rightChild().size++
+	assert.ErrorContains(t, tree.Validate(), "size")
+}
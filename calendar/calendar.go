@@ -0,0 +1,197 @@
+package calendar
+
+import (
+	"errors"
+	"iter"
+	"slices"
+	"time"
+
+	"github.com/qiuchengxuan/go-types/augmentedtree"
+)
+
+var (
+	ErrInvalidSlot = errors.New("not a valid slot")
+	ErrConflict    = errors.New("conflict with existing booking")
+)
+
+// Slot is a half-open time range [Start, End)
+type Slot struct{ Start, End time.Time }
+
+func (s Slot) valid() bool { return s.Start.Before(s.End) }
+
+func (s Slot) fits(duration time.Duration) bool {
+	return s.valid() && s.End.Sub(s.Start) >= duration
+}
+
+func (s Slot) equal(rhs Slot) bool { return s.Start.Equal(rhs.Start) && s.End.Equal(rhs.End) }
+
+func compareSlots(a, b Slot) int { return a.Start.Compare(b.Start) }
+
+func (s Slot) interval() augmentedtree.Interval[augmentedtree.Time] {
+	start, end := augmentedtree.NewTime(s.Start), augmentedtree.NewTime(s.End)
+	return augmentedtree.NewInterval(start, end, augmentedtree.Closed, augmentedtree.Open)
+}
+
+// Booking is a slot occupied by owner, for recurring booking it's a single occurrence
+type Booking[O any] struct {
+	Slot  Slot
+	Owner O
+}
+
+// Recurrence repeats slot for Count times, starts of adjacent occurrences are Every apart
+type Recurrence struct {
+	Every time.Duration
+	Count int
+}
+
+// series is a recurring booking, occurrences are computed on demand instead of being stored
+type series[O any] struct {
+	first      Slot
+	recurrence Recurrence
+	owner      O
+}
+
+func (s *series[O]) occurrence(index int) Slot {
+	offset := time.Duration(index) * s.recurrence.Every
+	return Slot{s.first.Start.Add(offset), s.first.End.Add(offset)}
+}
+
+// span covers from start of first occurrence to end of last occurrence
+func (s *series[O]) span() Slot {
+	return Slot{s.first.Start, s.occurrence(s.recurrence.Count - 1).End}
+}
+
+// occurrences iterates occurrences overlapping specified slot in order
+func (s *series[O]) occurrences(slot Slot) iter.Seq[Slot] {
+	return func(yield func(Slot) bool) {
+		index := 0 // first occurrence ends after slot start
+		if elapsed := slot.Start.Sub(s.first.End); elapsed >= 0 {
+			index = int(elapsed/s.recurrence.Every) + 1
+		}
+		for ; index < s.recurrence.Count; index++ {
+			occurrence := s.occurrence(index)
+			if !occurrence.Start.Before(slot.End) || !yield(occurrence) {
+				return
+			}
+		}
+	}
+}
+
+// Calendar books non-overlapping slots to owners, zero value is an empty calendar
+type Calendar[O any] struct {
+	bookings augmentedtree.Tree[augmentedtree.Time, Booking[O]]
+	series   augmentedtree.Tree[augmentedtree.Time, *series[O]] // keyed by span
+}
+
+// overlapping iterates one-off bookings and occurrences of recurring bookings overlapping slot,
+// not in order
+func (c *Calendar[O]) overlapping(slot Slot) iter.Seq[Booking[O]] {
+	return func(yield func(Booking[O]) bool) {
+		interval := slot.interval()
+		for entry := range c.bookings.Query(interval) {
+			if !yield(entry.Value()) {
+				return
+			}
+		}
+		for entry := range c.series.Query(interval) {
+			series := entry.Value()
+			for occurrence := range series.occurrences(slot) {
+				if !yield(Booking[O]{occurrence, series.owner}) {
+					return
+				}
+			}
+		}
+	}
+}
+
+func (c *Calendar[O]) conflicted(slot Slot) bool {
+	conflicted := false
+	c.overlapping(slot)(func(Booking[O]) bool {
+		conflicted = true
+		return false
+	})
+	return conflicted
+}
+
+// Book occupies slot for owner, fails with ErrConflict if slot overlaps any booking
+func (c *Calendar[O]) Book(slot Slot, owner O) error {
+	if !slot.valid() {
+		return ErrInvalidSlot
+	}
+	if c.conflicted(slot) {
+		return ErrConflict
+	}
+	c.bookings.Put(slot.interval(), Booking[O]{slot, owner})
+	return nil
+}
+
+// BookRecurring occupies every occurrence of slot repeated by recurrence for owner,
+// fails with ErrConflict if any occurrence overlaps any booking.
+// Occurrences are expanded lazily by queries, so long recurrence costs nothing but checking
+func (c *Calendar[O]) BookRecurring(first Slot, recurrence Recurrence, owner O) error {
+	if !first.valid() || recurrence.Count <= 0 {
+		return ErrInvalidSlot
+	}
+	if recurrence.Every < first.End.Sub(first.Start) { // occurrences overlap each other
+		return ErrInvalidSlot
+	}
+	series := &series[O]{first, recurrence, owner}
+	for index := range recurrence.Count {
+		if c.conflicted(series.occurrence(index)) {
+			return ErrConflict
+		}
+	}
+	c.series.Put(series.span().interval(), series)
+	return nil
+}
+
+// Cancel removes one-off booking of exactly the slot,
+// or recurring booking whose first occurrence is the slot
+func (c *Calendar[O]) Cancel(slot Slot) bool {
+	if c.bookings.Delete(slot.interval()) {
+		return true
+	}
+	entry := c.series.Ceiling(Slot{slot.Start, slot.Start}.interval())
+	if entry == nil || !entry.Value().first.equal(slot) {
+		return false
+	}
+	return c.series.Delete(entry.Interval())
+}
+
+// Conflicts returns bookings overlapping specified slot ordered by start time
+func (c *Calendar[O]) Conflicts(slot Slot) []Booking[O] {
+	conflicts := slices.Collect(c.overlapping(slot))
+	slices.SortFunc(conflicts, func(a, b Booking[O]) int {
+		return compareSlots(a.Slot, b.Slot)
+	})
+	return conflicts
+}
+
+// FreeSlots returns free slots not shorter than minDuration within the day of specified time,
+// day boundaries are decided by location of specified time
+func (c *Calendar[O]) FreeSlots(day time.Time, minDuration time.Duration) []Slot {
+	year, month, date := day.Date()
+	start := time.Date(year, month, date, 0, 0, 0, 0, day.Location())
+	bound := Slot{start, start.AddDate(0, 0, 1)}
+	var slots []Slot
+	// gaps between one-off bookings are further divided by occurrences of recurring bookings
+	for gap := range c.bookings.Gaps(bound.interval()) {
+		location := day.Location()
+		free := Slot{gap.Low().Time().In(location), gap.High().Time().In(location)}
+		var occurrences []Slot
+		for entry := range c.series.Query(gap) {
+			occurrences = slices.AppendSeq(occurrences, entry.Value().occurrences(free))
+		}
+		slices.SortFunc(occurrences, compareSlots)
+		for _, occurrence := range occurrences {
+			if slot := (Slot{free.Start, occurrence.Start}); slot.fits(minDuration) {
+				slots = append(slots, slot)
+			}
+			free.Start = occurrence.End
+		}
+		if free.fits(minDuration) {
+			slots = append(slots, free)
+		}
+	}
+	return slots
+}
@@ -0,0 +1,91 @@
+package calendar
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var day = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func at(hour, minute int) time.Time {
+	return day.Add(time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute)
+}
+
+func TestBook(t *testing.T) {
+	var calendar Calendar[string]
+	assert.ErrorIs(t, calendar.Book(Slot{at(10, 0), at(9, 0)}, "alice"), ErrInvalidSlot)
+	assert.NoError(t, calendar.Book(Slot{at(9, 0), at(10, 0)}, "alice"))
+	assert.NoError(t, calendar.Book(Slot{at(10, 0), at(11, 0)}, "bob"))
+	assert.ErrorIs(t, calendar.Book(Slot{at(9, 30), at(10, 30)}, "carol"), ErrConflict)
+	assert.NoError(t, calendar.Book(Slot{at(8, 0), at(9, 0)}, "carol"))
+
+	conflicts := calendar.Conflicts(Slot{at(9, 30), at(10, 30)})
+	assert.Equal(t, []Booking[string]{
+		{Slot{at(9, 0), at(10, 0)}, "alice"},
+		{Slot{at(10, 0), at(11, 0)}, "bob"},
+	}, conflicts)
+	assert.Empty(t, calendar.Conflicts(Slot{at(11, 0), at(12, 0)}))
+
+	assert.False(t, calendar.Cancel(Slot{at(9, 0), at(9, 30)}))
+	assert.True(t, calendar.Cancel(Slot{at(9, 0), at(10, 0)}))
+	assert.NoError(t, calendar.Book(Slot{at(9, 30), at(10, 0)}, "carol"))
+}
+
+func TestBookRecurring(t *testing.T) {
+	var calendar Calendar[string]
+	daily := Recurrence{24 * time.Hour, 365}
+	standup := Slot{at(9, 0), at(9, 15)}
+	assert.ErrorIs(t, calendar.BookRecurring(standup, Recurrence{time.Minute, 2}, "team"),
+		ErrInvalidSlot)
+	assert.ErrorIs(t, calendar.BookRecurring(standup, Recurrence{time.Hour, 0}, "team"),
+		ErrInvalidSlot)
+	assert.NoError(t, calendar.BookRecurring(standup, daily, "team"))
+
+	// occurrence in 100 days conflicts
+	later := Slot{at(24*100+9, 10), at(24*100+10, 0)}
+	assert.ErrorIs(t, calendar.Book(later, "alice"), ErrConflict)
+	assert.Equal(t, []Booking[string]{{Slot{at(24*100+9, 0), at(24*100+9, 15)}, "team"}},
+		calendar.Conflicts(later))
+	assert.NoError(t, calendar.Book(Slot{at(24*365+9, 0), at(24*365+10, 0)}, "alice"))
+
+	weekly := Recurrence{7 * 24 * time.Hour, 52}
+	assert.ErrorIs(t, calendar.BookRecurring(Slot{at(9, 10), at(9, 20)}, weekly, "bob"),
+		ErrConflict)
+	assert.NoError(t, calendar.BookRecurring(Slot{at(9, 15), at(9, 45)}, weekly, "bob"))
+	conflicts := calendar.Conflicts(Slot{at(24*7, 0), at(24*8, 0)})
+	assert.Equal(t, []Booking[string]{
+		{Slot{at(24*7+9, 0), at(24*7+9, 15)}, "team"},
+		{Slot{at(24*7+9, 15), at(24*7+9, 45)}, "bob"},
+	}, conflicts)
+
+	assert.False(t, calendar.Cancel(Slot{at(24+9, 0), at(24+9, 15)}))
+	assert.True(t, calendar.Cancel(standup))
+	assert.NoError(t, calendar.Book(later, "alice"))
+}
+
+func TestFreeSlots(t *testing.T) {
+	var calendar Calendar[string]
+	assert.Equal(t, []Slot{{at(0, 0), at(24, 0)}}, calendar.FreeSlots(at(12, 0), time.Hour))
+
+	assert.NoError(t, calendar.Book(Slot{at(0, 0), at(8, 0)}, "night"))
+	assert.NoError(t, calendar.Book(Slot{at(12, 0), at(12, 30)}, "lunch"))
+	assert.NoError(t, calendar.Book(Slot{at(18, 0), at(24, 0)}, "night"))
+	sync := Slot{at(8, 30), at(9, 0)}
+	err := calendar.BookRecurring(sync, Recurrence{time.Hour, 11}, "sync")
+	assert.ErrorIs(t, err, ErrConflict)
+	assert.NoError(t, calendar.BookRecurring(sync, Recurrence{time.Hour, 10}, "sync"))
+
+	assert.Equal(t, []Slot{
+		{at(8, 0), at(8, 30)}, {at(9, 0), at(9, 30)}, {at(10, 0), at(10, 30)},
+		{at(11, 0), at(11, 30)}, {at(13, 0), at(13, 30)}, {at(14, 0), at(14, 30)},
+		{at(15, 0), at(15, 30)}, {at(16, 0), at(16, 30)}, {at(17, 0), at(17, 30)},
+	}, calendar.FreeSlots(at(12, 0), 30*time.Minute))
+	assert.Empty(t, calendar.FreeSlots(at(12, 0), time.Hour))
+
+	assert.True(t, calendar.Cancel(sync))
+	assert.Equal(t, []Slot{{at(8, 0), at(12, 0)}, {at(12, 30), at(18, 0)}},
+		calendar.FreeSlots(at(12, 0), time.Hour))
+	assert.Equal(t, []Slot{{at(24, 0), at(48, 0)}}, calendar.FreeSlots(at(30, 0), time.Hour))
+}
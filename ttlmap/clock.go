@@ -0,0 +1,37 @@
+package ttlmap
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock tells current time which should never go backwards
+type Clock interface{ Now() time.Time }
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time { return time.Now() }
+
+// FakeClock is a manually advanced clock for testing, safe for concurrent use
+type FakeClock struct {
+	mutex sync.Mutex
+	now   time.Time
+}
+
+func NewFakeClock(now time.Time) *FakeClock { return &FakeClock{now: now} }
+
+func (c *FakeClock) Now() time.Time {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.now
+}
+
+// Advance moves clock forward by specified duration, negative duration is ignored
+func (c *FakeClock) Advance(duration time.Duration) {
+	if duration <= 0 {
+		return
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.now = c.now.Add(duration)
+}
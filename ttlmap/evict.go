@@ -0,0 +1,58 @@
+package ttlmap
+
+// EvictReason tells why an entry is removed from map
+type EvictReason uint8
+
+const (
+	Expired     EvictReason = iota // ttl elapsed since last access
+	Deleted                        // removed by Delete
+	Overwritten                    // value replaced by Put
+	Evicted                        // least recently used entry removed due to capacity
+)
+
+func (r EvictReason) String() string {
+	switch r {
+	case Expired:
+		return "expired"
+	case Deleted:
+		return "deleted"
+	case Overwritten:
+		return "overwritten"
+	case Evicted:
+		return "evicted"
+	}
+	return "unknown"
+}
+
+func (m *TTLMap[K, V]) remove(entry *entry[K, V], reason EvictReason) {
+	delete(m.entries, entry.key)
+	m.detach(entry)
+	if m.onEvict != nil {
+		m.onEvict(entry.key, entry.value, reason)
+	}
+}
+
+// OnEvict specifies callback invoked when entry is removed or its value is overwritten,
+// callback must not modify the map.
+//
+// Expired entries are removed lazily by Put and Get, use Expire to remove them proactively
+func (m *TTLMap[K, V]) OnEvict(fn func(key K, value V, reason EvictReason)) { m.onEvict = fn }
+
+// SetCapacity limits number of entries, least recently used entry is evicted
+// when putting new key into a full map, zero means unlimited
+func (m *TTLMap[K, V]) SetCapacity(capacity int) {
+	m.capacity = max(capacity, 0)
+	for m.capacity > 0 && len(m.entries) > m.capacity {
+		m.remove(m.head, Evicted)
+	}
+}
+
+// Expire removes all expired entries, returns number of entries removed.
+// Entries are ordered by expiry since access always moves entry to tail,
+// so it stops at the first entry not expired
+func (m *TTLMap[K, V]) Expire() int {
+	size := len(m.entries)
+	for m.expireOne() {
+	}
+	return size - len(m.entries)
+}
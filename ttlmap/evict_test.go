@@ -0,0 +1,148 @@
+package ttlmap
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOnEvict(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	ttlMap := New[int, int](time.Second, clock)
+	var evicted []string
+	ttlMap.OnEvict(func(key, value int, reason EvictReason) {
+		evicted = append(evicted, fmt.Sprintf("%d:%d:%s", key, value, reason))
+	})
+	ttlMap.Put(0, 0)
+	ttlMap.Put(1, 1)
+	ttlMap.Put(1, 2)
+	ttlMap.Put(0, 3)
+	assert.True(t, ttlMap.Delete(1))
+	assert.Equal(t, []string{"1:1:overwritten", "0:0:overwritten", "1:2:deleted"}, evicted)
+
+	evicted = evicted[:0]
+	clock.Advance(2 * time.Second)
+	_, ok := ttlMap.Get(0)
+	assert.False(t, ok)
+	ttlMap.Put(2, 2)
+	ttlMap.Put(3, 3)
+	ttlMap.SetCapacity(2)
+	ttlMap.Put(4, 4)
+	assert.Equal(t, []string{"0:3:expired", "2:2:evicted"}, evicted)
+
+	evicted = evicted[:0]
+	ttlMap.SetCapacity(1)
+	assert.Equal(t, []string{"3:3:evicted"}, evicted)
+	clock.Advance(2 * time.Second)
+	assert.Equal(t, 1, ttlMap.Expire())
+	assert.Equal(t, []string{"3:3:evicted", "4:4:expired"}, evicted)
+	assert.Zero(t, ttlMap.Len())
+}
+
+func TestOnEvictPutExpired(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	ttlMap := New[int, int](time.Second, clock)
+	var evicted []string
+	ttlMap.OnEvict(func(key, value int, reason EvictReason) {
+		evicted = append(evicted, fmt.Sprintf("%d:%d:%s", key, value, reason))
+	})
+	ttlMap.Put(0, 0)
+	ttlMap.Put(1, 1)
+	ttlMap.Put(2, 2)
+	clock.Advance(time.Second + time.Millisecond)
+	ttlMap.Put(2, 3) // Put reclaims at most 2 expired entries, so 2 is not reclaimed yet
+	assert.Equal(t, []string{"0:0:expired", "1:1:expired", "2:2:expired"}, evicted)
+	value, ok := ttlMap.Get(2)
+	assert.True(t, ok)
+	assert.Equal(t, 3, value)
+}
+
+func TestSyncTTLMapOnEvict(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	ttlMap := NewSync[int, int](time.Second, func(key int) uint64 { return uint64(key) }, clock)
+	evicted := map[int]EvictReason{}
+	ttlMap.OnEvict(func(key, _ int, reason EvictReason) { evicted[key] = reason })
+	ttlMap.SetCapacity(2) // capacity is shared by keys of different shards
+	ttlMap.Put(0, 0)
+	ttlMap.Put(1, 1)
+	clock.Advance(time.Second / 2)
+	ttlMap.Get(0) // 1 becomes least recently used
+	ttlMap.Put(2, 2)
+	assert.Equal(t, map[int]EvictReason{1: Evicted}, evicted)
+	ttlMap.Put(2, 3) // overwriting doesn't evict
+	assert.Equal(t, map[int]EvictReason{1: Evicted, 2: Overwritten}, evicted)
+	assert.Equal(t, 2, ttlMap.Len())
+
+	clock.Advance(time.Second / 2)
+	_, ok := ttlMap.Get(0)
+	assert.True(t, ok)
+	clock.Advance(time.Second/2 + time.Millisecond)
+	ttlMap.Put(3, 3) // expired entry is removed instead of least recently used one
+	assert.Equal(t, map[int]EvictReason{1: Evicted, 2: Expired}, evicted)
+	assert.Equal(t, 2, ttlMap.Len())
+
+	ttlMap.SetCapacity(1)
+	assert.Equal(t, map[int]EvictReason{1: Evicted, 2: Expired, 0: Evicted}, evicted)
+	assert.Equal(t, 1, ttlMap.Len())
+	ttlMap.SetCapacity(0)
+	for i := range 100 {
+		ttlMap.Put(i, i)
+	}
+	assert.Equal(t, 100, ttlMap.Len())
+}
+
+func TestSyncTTLMapCapacityConcurrent(t *testing.T) {
+	ttlMap := NewSync[int, int](time.Minute, func(key int) uint64 { return uint64(key) })
+	ttlMap.SetCapacity(100)
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range 1000 {
+				ttlMap.Put(i*1000+j, j)
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 100, ttlMap.Len())
+}
+
+func TestSyncTTLMapExpireAfterRead(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	ttlMap := NewSync[string, int](time.Second, func(string) uint64 { return 0 }, clock)
+	var evicted []string
+	ttlMap.OnEvict(func(key string, _ int, reason EvictReason) {
+		evicted = append(evicted, key+":"+reason.String())
+	})
+	ttlMap.Put("a", 0)
+	ttlMap.Put("b", 1)
+	ttlMap.Put("c", 2)
+	clock.Advance(time.Second / 2)
+	_, ok := ttlMap.Get("a") // a expires after b and c
+	assert.True(t, ok)
+	clock.Advance(time.Second/2 + time.Millisecond)
+	assert.Equal(t, 2, ttlMap.Expire())
+	assert.Equal(t, []string{"b:expired", "c:expired"}, evicted)
+	assert.Equal(t, 1, ttlMap.Len())
+
+	clock.Advance(time.Second / 2)
+	_, ok = ttlMap.Get("a")
+	assert.False(t, ok)
+	assert.Zero(t, ttlMap.Len())
+	assert.Equal(t, []string{"b:expired", "c:expired", "a:expired"}, evicted)
+
+	ttlMap.Put("a", 0)
+	ttlMap.Put("b", 1)
+	ttlMap.Put("c", 2)
+	clock.Advance(time.Second / 2)
+	ttlMap.Get("a")
+	clock.Advance(time.Second/2 + time.Millisecond)
+	ttlMap.Get("b") // expired
+	assert.Equal(t, 1, ttlMap.Expire())
+	_, ok = ttlMap.Get("a")
+	assert.True(t, ok)
+}
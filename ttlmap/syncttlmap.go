@@ -0,0 +1,166 @@
+package ttlmap
+
+import (
+	"iter"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const shards = 32
+
+type shard[K comparable, V any] struct {
+	mutex  sync.Mutex
+	ttlMap TTLMap[K, V]
+}
+
+// SyncTTLMap is a concurrent-safe TTLMap, keys are spread over shards by hash
+// and each shard is guarded by its own lock, so operations on different shards
+// don't contend with each other while keeping sliding expiry of TTLMap.
+type SyncTTLMap[K comparable, V any] struct {
+	hash   func(K) uint64
+	shards [shards]shard[K, V]
+	// entries of all shards plus slots reserved by Put, see SetCapacity
+	size     atomic.Int64
+	capacity atomic.Int64
+}
+
+func (m *SyncTTLMap[K, V]) shard(key K) *shard[K, V] { return &m.shards[m.hash(key)%shards] }
+
+// locked invokes fn with shard lock held, number of entries added or removed is accounted
+func (m *SyncTTLMap[K, V]) locked(shard *shard[K, V], fn func(*TTLMap[K, V])) {
+	shard.mutex.Lock()
+	defer shard.mutex.Unlock()
+	size := shard.ttlMap.Len()
+	fn(&shard.ttlMap)
+	m.size.Add(int64(shard.ttlMap.Len() - size))
+}
+
+func (m *SyncTTLMap[K, V]) Put(key K, value V) {
+	shard := m.shard(key)
+	if capacity := m.capacity.Load(); capacity > 0 {
+		exists := false
+		m.locked(shard, func(ttlMap *TTLMap[K, V]) { _, exists = ttlMap.entries[key] })
+		if !exists {
+			// reserve a slot for new key so that concurrent Put never exceeds capacity
+			if m.size.Add(1) > capacity {
+				m.evict()
+			}
+			defer m.size.Add(-1)
+		}
+	}
+	m.locked(shard, func(ttlMap *TTLMap[K, V]) { ttlMap.Put(key, value) })
+}
+
+func (m *SyncTTLMap[K, V]) Get(key K) (value V, ok bool) {
+	m.locked(m.shard(key), func(ttlMap *TTLMap[K, V]) { value, ok = ttlMap.Get(key) })
+	return value, ok
+}
+
+func (m *SyncTTLMap[K, V]) Delete(key K) (ok bool) {
+	m.locked(m.shard(key), func(ttlMap *TTLMap[K, V]) { ok = ttlMap.Delete(key) })
+	return ok
+}
+
+// each invokes fn on every shard in order with shard lock held
+func (m *SyncTTLMap[K, V]) each(fn func(*TTLMap[K, V])) {
+	for i := range m.shards {
+		m.locked(&m.shards[i], fn)
+	}
+}
+
+// evict removes an expired entry if any, otherwise least recently used entry of all shards,
+// which is the head expiring earliest since each shard is ordered by expiry.
+// Returns false if every shard is empty
+func (m *SyncTTLMap[K, V]) evict() bool {
+	for {
+		var oldest *shard[K, V]
+		var expireAt time.Duration
+		for i := range m.shards {
+			shard := &m.shards[i]
+			m.locked(shard, func(ttlMap *TTLMap[K, V]) {
+				head := ttlMap.head
+				if head != nil && (oldest == nil || head.expireAt < expireAt) {
+					oldest, expireAt = shard, head.expireAt
+				}
+			})
+		}
+		if oldest == nil {
+			return false
+		}
+		removed := false
+		m.locked(oldest, func(ttlMap *TTLMap[K, V]) {
+			// shard may be emptied by others after scanning
+			if removed = ttlMap.head != nil; removed && !ttlMap.expireOne() {
+				ttlMap.remove(ttlMap.head, Evicted)
+			}
+		})
+		if removed {
+			return true
+		}
+	}
+}
+
+// OnEvict is like TTLMap.OnEvict, callback is invoked with shard lock held
+func (m *SyncTTLMap[K, V]) OnEvict(fn func(key K, value V, reason EvictReason)) {
+	m.each(func(ttlMap *TTLMap[K, V]) { ttlMap.OnEvict(fn) })
+}
+
+// SetCapacity is like TTLMap.SetCapacity, capacity is shared by all shards.
+// Putting new key into a full map evicts an expired entry if found first,
+// otherwise the least recently used one, which scans heads of all shards
+func (m *SyncTTLMap[K, V]) SetCapacity(capacity int) {
+	m.capacity.Store(int64(max(capacity, 0)))
+	for capacity > 0 && m.size.Load() > int64(capacity) && m.evict() {
+	}
+}
+
+func (m *SyncTTLMap[K, V]) Expire() int {
+	expired := 0
+	m.each(func(ttlMap *TTLMap[K, V]) { expired += ttlMap.Expire() })
+	return expired
+}
+
+// Len sums entries of each shard, which is not a consistent snapshot under concurrent Put
+func (m *SyncTTLMap[K, V]) Len() int {
+	size := 0
+	m.each(func(ttlMap *TTLMap[K, V]) { size += ttlMap.Len() })
+	return size
+}
+
+// Keys collects keys of each shard under shard lock and yields them after unlocking,
+// so map could be accessed inside iteration
+func (m *SyncTTLMap[K, V]) Keys() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for i := range m.shards {
+			for _, key := range m.shards[i].keys() {
+				if !yield(key) {
+					return
+				}
+			}
+		}
+	}
+}
+
+func (s *shard[K, V]) keys() []K {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return slices.Collect(s.ttlMap.Keys())
+}
+
+// NewSync is like New but returns a concurrent-safe map, hash decides shard of key
+// and must not be nil, e.g. func(key string) uint64 { return maphash.String(seed, key) }.
+// Clock must be concurrent-safe too
+func NewSync[K comparable, V any](
+	ttl time.Duration, hash func(K) uint64, clock ...Clock,
+) *SyncTTLMap[K, V] {
+	if hash == nil {
+		panic("Hash function not specified")
+	}
+	m := &SyncTTLMap[K, V]{hash: hash}
+	for i := range m.shards {
+		m.shards[i].ttlMap = New[K, V](ttl, clock...)
+	}
+	return m
+}
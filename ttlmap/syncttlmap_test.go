@@ -0,0 +1,143 @@
+package ttlmap
+
+import (
+	"math/rand"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func intHash(key int) uint64 { return uint64(key) }
+
+func TestSyncTTLMap(t *testing.T) {
+	ttlMap := NewSync[int, int](time.Second, intHash)
+	_, ok := ttlMap.Get(0)
+	assert.False(t, ok)
+
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := range 100 {
+				ttlMap.Put(i*100+j, j)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := range 100 {
+				ttlMap.Get(j)
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 800, ttlMap.Len())
+	v, ok := ttlMap.Get(101)
+	assert.True(t, ok)
+	assert.Equal(t, 1, v)
+	assert.True(t, ttlMap.Delete(101))
+	_, ok = ttlMap.Get(101)
+	assert.False(t, ok)
+	count := 0
+	for range ttlMap.Keys() {
+		count++
+	}
+	assert.Equal(t, 799, count)
+}
+
+func TestSyncTTLMapShards(t *testing.T) {
+	assert.Panics(t, func() { NewSync[int, int](time.Second, nil) })
+	ttlMap := NewSync[int, int](time.Second, intHash)
+	for i := range shards * 4 {
+		ttlMap.Put(i, i)
+	}
+	for i := range ttlMap.shards {
+		assert.Equal(t, 4, ttlMap.shards[i].ttlMap.Len())
+	}
+	count := 0
+	for range ttlMap.Keys() {
+		if count++; count == shards+1 {
+			break
+		}
+	}
+	assert.Equal(t, shards+1, count)
+	ttlMap.Put(0, 1) // lock of shard left by break is released
+	value, _ := ttlMap.Get(0)
+	assert.Equal(t, 1, value)
+
+	count = 0
+	for key := range ttlMap.Keys() { // access inside iteration
+		_, ok := ttlMap.Get(key)
+		assert.True(t, ok)
+		ttlMap.Delete(key)
+		count++
+	}
+	assert.Equal(t, shards*4, count)
+	assert.Zero(t, ttlMap.Len())
+}
+
+func TestSyncTTLMapFakeClock(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	ttlMap := NewSync[int, int](time.Second, intHash, clock)
+	ttlMap.Put(0, 0)
+	ttlMap.Put(1, 1)
+	clock.Advance(time.Second)
+	_, ok := ttlMap.Get(0)
+	assert.True(t, ok)
+	clock.Advance(time.Millisecond)
+	_, ok = ttlMap.Get(1)
+	assert.False(t, ok)
+
+	ttlMap.Put(2, 2)
+	assert.Equal(t, 2, ttlMap.Len())
+	_, ok = ttlMap.Get(0)
+	assert.True(t, ok)
+	clock.Advance(2 * time.Second)
+	ttlMap.Put(3, 3)
+	assert.Equal(t, 2, ttlMap.Expire()) // expired entries of other shards
+	assert.Equal(t, 1, ttlMap.Len())
+}
+
+func BenchmarkSyncTTLMapGet(b *testing.B) {
+	ttlMap := NewSync[int, int](time.Minute, intHash)
+	for i := range 1024 {
+		ttlMap.Put(i, i)
+	}
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for i := rand.Int(); pb.Next(); i++ { // goroutines start at different keys
+			ttlMap.Get(i % 1024)
+		}
+	})
+}
+
+func BenchmarkMutexTTLMapGet(b *testing.B) {
+	ttlMap := New[int, int](time.Minute)
+	var mutex sync.Mutex
+	for i := range 1024 {
+		ttlMap.Put(i, i)
+	}
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for i := rand.Int(); pb.Next(); i++ { // goroutines start at different keys
+			mutex.Lock()
+			ttlMap.Get(i % 1024)
+			mutex.Unlock()
+		}
+	})
+}
+
+func BenchmarkSyncTTLMapMixed(b *testing.B) {
+	ttlMap := NewSync[int, int](time.Minute, intHash)
+	b.RunParallel(func(pb *testing.PB) {
+		for i := rand.Int(); pb.Next(); i++ { // goroutines start at different keys
+			if i%8 == 0 {
+				ttlMap.Put(i%1024, i)
+			} else {
+				ttlMap.Get(i % 1024)
+			}
+		}
+	})
+}
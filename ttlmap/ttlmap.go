@@ -6,8 +6,6 @@ import (
 	"time"
 )
 
-var boot = time.Now()
-
 type entry[K comparable, T any] struct {
 	expireAt   time.Duration
 	key        K
@@ -19,21 +17,35 @@ type TTLMap[K comparable, V any] struct {
 	ttl     time.Duration
 	entries map[K]*entry[K, V]
 	head    *entry[K, V]
+	clock   Clock
+	epoch   time.Time
+	// see evict.go
+	capacity int
+	onEvict  func(key K, value V, reason EvictReason)
 }
 
+// now returns time elapsed since map created
+func (m *TTLMap[K, V]) now() time.Duration { return m.clock.Now().Sub(m.epoch) }
+
 func (m *TTLMap[K, V]) detach(entry *entry[K, V]) {
 	if entry == entry.next {
 		entry.prev, entry.next = nil, nil
 		m.head = nil
 		return
 	}
+	if entry == m.head {
+		m.head = entry.next
+	}
 	entry.prev.next, entry.next.prev = entry.next, entry.prev
 }
 
 func (m *TTLMap[K, V]) expireOne() bool {
-	if entry := m.head; entry != nil && time.Since(boot) > entry.expireAt {
-		delete(m.entries, entry.key)
-		m.detach(entry)
+	entry := m.head
+	if entry == nil {
+		return false
+	}
+	if m.now() > entry.expireAt {
+		m.remove(entry, Expired)
 		return true
 	}
 	return false
@@ -42,7 +54,8 @@ func (m *TTLMap[K, V]) expireOne() bool {
 func (m *TTLMap[K, V]) tail() *entry[K, V] { return m.head.prev }
 
 func (m *TTLMap[K, V]) attach(key K, value V) {
-	entry := &entry[K, V]{time.Since(boot) + m.ttl, key, value, nil, m.head}
+	expireAt := m.now() + m.ttl
+	entry := &entry[K, V]{expireAt: expireAt, key: key, value: value, next: m.head}
 	m.entries[key] = entry
 	if m.head == nil {
 		entry.prev, entry.next = entry, entry
@@ -58,13 +71,23 @@ func (m *TTLMap[K, V]) Put(key K, value V) {
 	if m.expireOne() {
 		m.expireOne() // extra call to ensure convergence
 	}
-	if existing, ok := m.entries[key]; ok {
+	existing, ok := m.entries[key]
+	if ok && m.now() > existing.expireAt { // not reclaimed yet
+		m.remove(existing, Expired)
+		ok = false
+	}
+	switch {
+	case ok:
+		if m.onEvict != nil {
+			m.onEvict(key, existing.value, Overwritten)
+		}
 		if existing == m.tail() {
-			existing.expireAt, existing.value = time.Since(boot)+m.ttl, value
+			existing.expireAt, existing.value = m.now()+m.ttl, value
 			return
-		} else {
-			m.detach(existing)
 		}
+		m.detach(existing)
+	case m.capacity > 0 && len(m.entries) >= m.capacity:
+		m.remove(m.head, Evicted)
 	}
 	m.attach(key, value)
 }
@@ -77,13 +100,12 @@ func (m *TTLMap[K, V]) Get(key K) (V, bool) {
 		var value V
 		return value, false
 	}
-	if time.Since(boot) > entry.expireAt {
-		delete(m.entries, key)
-		m.detach(entry)
+	if m.now() > entry.expireAt {
+		m.remove(entry, Expired)
 		return entry.value, false
 	}
 	if entry == m.tail() {
-		entry.expireAt = time.Since(boot) + m.ttl
+		entry.expireAt = m.now() + m.ttl
 		return entry.value, true
 	}
 	m.detach(entry)
@@ -96,8 +118,7 @@ func (m *TTLMap[K, V]) Delete(key K) bool {
 	if !ok {
 		return false
 	}
-	delete(m.entries, key)
-	m.detach(entry)
+	m.remove(entry, Deleted)
 	return true
 }
 
@@ -105,7 +126,13 @@ func (m *TTLMap[K, V]) Keys() iter.Seq[K] {
 	return maps.Keys(m.entries)
 }
 
-// ttl should not be too long, otherwise may cause too much memory consumption
-func New[K comparable, V any](ttl time.Duration) TTLMap[K, V] {
-	return TTLMap[K, V]{ttl, make(map[K]*entry[K, V]), nil}
+// ttl should not be too long, otherwise may cause too much memory consumption.
+// Clock defaults to system clock if not specified, FakeClock could be used for testing
+func New[K comparable, V any](ttl time.Duration, clock ...Clock) TTLMap[K, V] {
+	m := TTLMap[K, V]{ttl: ttl, entries: make(map[K]*entry[K, V]), clock: systemClock{}}
+	if len(clock) > 0 {
+		m.clock = clock[0]
+	}
+	m.epoch = m.clock.Now()
+	return m
 }
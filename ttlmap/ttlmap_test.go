@@ -50,3 +50,23 @@ func TestExpireOnPut(t *testing.T) {
 	assert.True(t, ttlMap.Delete(2))
 	assert.Equal(t, ttlMap.Len(), 0)
 }
+
+func TestFakeClock(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	ttlMap := New[int, int](time.Second, clock)
+	ttlMap.Put(0, 0)
+	ttlMap.Put(1, 1)
+
+	clock.Advance(time.Second)
+	_, ok := ttlMap.Get(0) // extends expiry of 0
+	assert.True(t, ok)
+	clock.Advance(-time.Hour)
+	clock.Advance(time.Millisecond)
+	_, ok = ttlMap.Get(1)
+	assert.False(t, ok)
+
+	clock.Advance(time.Second)
+	_, ok = ttlMap.Get(0)
+	assert.False(t, ok)
+	assert.Zero(t, ttlMap.Len())
+}